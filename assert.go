@@ -0,0 +1,36 @@
+package simplelog
+
+import "fmt"
+
+// Assert writes Error message formatted by `format` if condition `cond` is false. It returns `cond`.
+func (l *Logger) Assert(cond bool, format string, a ...any) bool {
+	if !cond {
+		l.p(LogLevelError, fmt.Sprintf(format, a...))
+	}
+
+	return cond
+}
+
+// AssertFatal writes Fatal message formatted by `format` and terminates program if condition `cond` is false.
+func (l *Logger) AssertFatal(cond bool, format string, a ...any) {
+	if !cond {
+		l.Fatalf(format, a...)
+	}
+}
+
+// Check writes Error message `<msg>: <err>` if `err` is not nil. It reports whether `err` is nil.
+func (l *Logger) Check(err error, msg string) bool {
+	if err != nil {
+		l.logError(LogLevelError, msg+": "+err.Error(), err)
+	}
+
+	return err == nil
+}
+
+// CheckFatal writes Fatal message `<msg>: <err>` and terminates program if `err` is not nil.
+func (l *Logger) CheckFatal(err error, msg string) {
+	if err != nil {
+		l.logError(LogLevelFatal, msg+": "+err.Error(), err)
+		l.fatalExit()
+	}
+}
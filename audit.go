@@ -0,0 +1,212 @@
+package simplelog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// maxAuditLineSize is a maximum size of audit log line accepted by VerifyAudit
+const maxAuditLineSize = 64 * 1024 * 1024
+
+// AuditEncoder makes tamper-evident audit log: each line is prefixed with SHA-256 hash of previous line hash
+// and line payload, so modification, reordering or removal of lines followed by other lines breaks the
+// chain. Hash chain is not keyed, so removal of trailing lines or rewriting of whole log can not be detected
+// by log itself: hash returned by Head must be stored outside of log and checked by VerifyAuditHead. New
+// lines inside payload are escaped to keep one entry per line.
+type AuditEncoder struct {
+	// Encoder of line payload
+	Encoder Encoder
+
+	// hash of last written line
+	last [sha256.Size]byte
+
+	// hash of line before last one, it is restored if last line is not written
+	prev [sha256.Size]byte
+
+	mu sync.Mutex
+}
+
+// NewAuditEncoder returns new audit encoder which starts new hash chain.
+func NewAuditEncoder(enc Encoder) *AuditEncoder {
+	return &AuditEncoder{Encoder: enc}
+}
+
+// Encode implements Encoder.
+func (enc *AuditEncoder) Encode(e *Entry) ([]byte, error) {
+	payload, err := enc.Encoder.Encode(e)
+	if err != nil {
+		return nil, err
+	}
+
+	payload = bytes.TrimSuffix(payload, []byte{'\n'})
+	payload = bytes.ReplaceAll(payload, []byte{'\n'}, []byte(`\n`))
+
+	enc.mu.Lock()
+	defer enc.mu.Unlock()
+
+	enc.prev = enc.last
+	enc.last = auditHash(enc.last, payload)
+
+	line := make([]byte, 0, hex.EncodedLen(sha256.Size)+len(payload)+2)
+	line = hex.AppendEncode(line, enc.last[:])
+	line = append(line, ' ')
+	line = append(line, payload...)
+	line = append(line, '\n')
+
+	return line, nil
+}
+
+// Head returns hex-encoded hash of last written line, or empty string if no lines are written. Hash should be
+// stored outside of audit log, e.g. sent to other system after each write or periodically.
+func (enc *AuditEncoder) Head() string {
+	enc.mu.Lock()
+	defer enc.mu.Unlock()
+
+	if enc.last == ([sha256.Size]byte{}) {
+		return ""
+	}
+
+	return hex.EncodeToString(enc.last[:])
+}
+
+// ordered implements orderedEncoder: hash chain must follow order of written lines.
+func (enc *AuditEncoder) ordered() {}
+
+// rollback implements rollbackEncoder: chain continues from previous line if last line is not written.
+func (enc *AuditEncoder) rollback() {
+	enc.mu.Lock()
+	enc.last = enc.prev
+	enc.mu.Unlock()
+}
+
+// Resume verifies existing audit log `r` and continues its hash chain.
+func (enc *AuditEncoder) Resume(r io.Reader) error {
+	last, _, err := verifyAudit(r, nil)
+	if err != nil {
+		return err
+	}
+
+	enc.mu.Lock()
+	enc.last = last
+	enc.mu.Unlock()
+
+	return nil
+}
+
+// AddAuditOutput adds append-only audit log output to file `path`. Existing file is verified and its hash
+// chain is continued.
+func (l *Logger) AddAuditOutput(path string, enc Encoder) (*Output, error) {
+	auditEncoder := NewAuditEncoder(enc)
+
+	f, err := os.Open(path)
+	if err == nil {
+		err = auditEncoder.Resume(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+
+	file, err := OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.AddOutput(auditEncoder, file), nil
+}
+
+// VerifyAudit verifies hash chain of audit log `r` and returns number of verified lines. Returned error
+// describes first broken line.
+func VerifyAudit(r io.Reader) (n int, err error) {
+	_, n, err = verifyAudit(r, nil)
+
+	return n, err
+}
+
+// VerifyAuditHead verifies audit log `r` like VerifyAudit does and checks that it contains line with hash
+// `head` returned by Head earlier, so removal of trailing lines or rewriting of whole log is detected. Lines
+// written after head was taken are accepted. Empty head matches any log.
+func VerifyAuditHead(r io.Reader, head string) (n int, err error) {
+	var want [sha256.Size]byte
+	if head != "" {
+		if hex.DecodedLen(len(head)) != sha256.Size {
+			return 0, fmt.Errorf("malformed audit log head: %q", head)
+		}
+		if _, err := hex.Decode(want[:], []byte(head)); err != nil {
+			return 0, fmt.Errorf("malformed audit log head: %w", err)
+		}
+	}
+
+	found := head == ""
+	_, n, err = verifyAudit(r, func(hash [sha256.Size]byte) {
+		found = found || hash == want
+	})
+	if err != nil {
+		return n, err
+	}
+
+	if !found {
+		return n, errors.New("audit log does not contain head line: log is truncated or rewritten")
+	}
+
+	return n, nil
+}
+
+// verifyAudit verifies hash chain of audit log `r` calling `f` with hash of each verified line if it is not
+// nil. It returns hash of last line and number of lines.
+func verifyAudit(r io.Reader, f func(hash [sha256.Size]byte)) (last [sha256.Size]byte, n int, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxAuditLineSize)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		hashHex, payload, ok := bytes.Cut(line, []byte{' '})
+		if !ok || hex.DecodedLen(len(hashHex)) != sha256.Size {
+			return last, n, fmt.Errorf("audit log line %d: malformed line", n+1)
+		}
+
+		var hash [sha256.Size]byte
+		if _, err := hex.Decode(hash[:], hashHex); err != nil {
+			return last, n, fmt.Errorf("audit log line %d: malformed hash: %w", n+1, err)
+		}
+
+		if hash != auditHash(last, payload) {
+			return last, n, fmt.Errorf("audit log line %d: hash mismatch", n+1)
+		}
+
+		if f != nil {
+			f(hash)
+		}
+
+		last = hash
+		n++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return last, n, fmt.Errorf("read audit log: %w", err)
+	}
+
+	return last, n, nil
+}
+
+// auditHash returns hash of line with payload `payload` following line with hash `prev`.
+func auditHash(prev [sha256.Size]byte, payload []byte) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write(prev[:])
+	h.Write(payload)
+
+	var hash [sha256.Size]byte
+	h.Sum(hash[:0])
+
+	return hash
+}
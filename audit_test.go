@@ -0,0 +1,132 @@
+package simplelog
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// auditLog returns audit log of messages `messages` and hash of its last line.
+func auditLog(t *testing.T, messages ...string) (string, string) {
+	t.Helper()
+
+	enc := NewAuditEncoder(&TextEncoder{})
+	buf := new(bytes.Buffer)
+	for _, message := range messages {
+		b, err := enc.Encode(&Entry{Time: time.Now(), Level: LogLevelInfo, Message: message})
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf.Write(b)
+	}
+
+	return buf.String(), enc.Head()
+}
+
+func TestVerifyAudit(t *testing.T) {
+	log, _ := auditLog(t, "a", "b\nc", "d")
+	lines := strings.SplitAfter(log, "\n")
+
+	tests := []struct {
+		name    string
+		log     string
+		n       int
+		wantErr bool
+	}{
+		{"valid", log, 3, false},
+		{"modified", strings.Replace(log, "|INF| b", "|INF| x", 1), 1, true},
+		{"removed", lines[0] + lines[2], 1, true},
+		{"reordered", lines[1] + lines[0] + lines[2], 0, true},
+		{"truncated", lines[0] + lines[1], 2, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n, err := VerifyAudit(strings.NewReader(test.log))
+			if n != test.n || (err != nil) != test.wantErr {
+				t.Errorf("VerifyAudit() = %d, %v, want %d lines", n, err, test.n)
+			}
+		})
+	}
+}
+
+func TestVerifyAuditHead(t *testing.T) {
+	log, head := auditLog(t, "a", "b", "c")
+	rewritten, _ := auditLog(t, "x")
+	lines := strings.SplitAfter(log, "\n")
+
+	tests := []struct {
+		name    string
+		log     string
+		head    string
+		wantErr bool
+	}{
+		{"valid", log, head, false},
+		{"empty head", log, "", false},
+		{"appended", log + lines[0], head, true},
+		{"truncated", lines[0] + lines[1], head, true},
+		{"rewritten", rewritten, head, true},
+		{"malformed head", log, "abc", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := VerifyAuditHead(strings.NewReader(test.log), test.head); (err != nil) != test.wantErr {
+				t.Errorf("VerifyAuditHead() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAuditResume(t *testing.T) {
+	log, head := auditLog(t, "a", "b")
+
+	enc := NewAuditEncoder(&TextEncoder{})
+	if err := enc.Resume(strings.NewReader(log)); err != nil {
+		t.Fatal(err)
+	}
+	if enc.Head() != head {
+		t.Errorf("resumed head is %s, want %s", enc.Head(), head)
+	}
+
+	b, err := enc.Encode(&Entry{Level: LogLevelInfo, Message: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := VerifyAuditHead(strings.NewReader(log+string(b)), head); n != 3 || err != nil {
+		t.Errorf("VerifyAuditHead() = %d, %v, want 3 lines", n, err)
+	}
+}
+
+// failingWriter is a writer which fails writes while `fail` is set
+type failingWriter struct {
+	bytes.Buffer
+	fail bool
+}
+
+func (w *failingWriter) Write(p []byte) (n int, err error) {
+	if w.fail {
+		return 0, errors.New("disk is full")
+	}
+
+	return w.Buffer.Write(p)
+}
+
+func TestAuditOutputWriteError(t *testing.T) {
+	w := new(failingWriter)
+	l := NewLogger(io.Discard)
+	l.AddOutput(NewAuditEncoder(&TextEncoder{}), w)
+
+	l.Info("a")
+	w.fail = true
+	l.Info("b")
+	w.fail = false
+	l.Info("c")
+
+	if n, err := VerifyAudit(strings.NewReader(w.String())); n != 2 || err != nil {
+		t.Errorf("VerifyAudit() = %d, %v, want 2 lines", n, err)
+	}
+}
@@ -0,0 +1,399 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stage is a stage of operation with its share of overall completion shown by progress bar
+type Stage struct {
+	Name string
+
+	// Weight is a share of stage in overall completion relative to weights of other stages
+	Weight float64
+}
+
+// barFrameInterval is an interval of redrawing of indeterminate bar
+const barFrameInterval = 100 * time.Millisecond
+
+// barSegmentWidth is a width of bouncing segment of indeterminate bar
+const barSegmentWidth = 3
+
+// barUnit is a unit of work of bar shown with its completion
+type barUnit int
+
+const (
+	barUnitNone barUnit = iota
+	barUnitBytes
+	barUnitItems
+)
+
+// Bar shows completion of operation as progress messages, e.g. `download [████░░░░░░] 40%`. Operation may
+// consist of weighted stages, so bar advances across stages without jumping back to 0% on each stage.
+// Indeterminate bar shows bouncing segment and elapsed time instead of completion.
+type Bar struct {
+	logger *Logger
+
+	// Width of bar in characters
+	Width int
+
+	title  string
+	stages []Stage
+
+	// index of current stage and weight of finished stages
+	stage int
+	done  float64
+
+	// completion of current stage
+	current float64
+	total   float64
+
+	start time.Time
+
+	// unit of work shown with amounts, rate and ETA
+	unit barUnit
+
+	// is total amount of work unknown, number of drawn frames and channel which stops redrawing
+	indeterminate bool
+	frame         int
+	stop          chan struct{}
+
+	// is operation finished or failed and callback called after it
+	finished   bool
+	onComplete func(err error)
+
+	mu sync.Mutex
+}
+
+// NewBar returns progress bar of operation `title` with `total` units of work.
+func (l *Logger) NewBar(title string, total float64) *Bar {
+	return &Bar{
+		logger: l,
+		Width:  defaultBarWidth,
+		title:  title,
+		stages: []Stage{{Name: title, Weight: 1}},
+		total:  total,
+		start:  l.now()}
+}
+
+// NewByteProgress returns progress bar of transfer of `total` bytes which shows humanized amounts, rate and
+// ETA, e.g. `[████░░░░░░] 40% 2.0 MiB/5.0 MiB 1.0 MiB/s ETA 3s`.
+func (l *Logger) NewByteProgress(total int64) *Bar {
+	b := l.NewBar("", float64(total))
+	b.unit = barUnitBytes
+
+	return b
+}
+
+// NewCounterProgress returns progress bar of processing of `total` items which shows counts and ETA, e.g.
+// `[████░░░░░░] 22% 1234/5678 items ETA 3s`.
+func (l *Logger) NewCounterProgress(total int) *Bar {
+	b := l.NewBar("", float64(total))
+	b.unit = barUnitItems
+
+	return b
+}
+
+// NewStagedBar returns progress bar of operation `title` consisting of stages `stages`, e.g.
+// `{"download", 70}, {"extract", 20}, {"verify", 10}`. Bar starts at first stage with unknown amount of
+// work, call Stage to set it.
+func (l *Logger) NewStagedBar(title string, stages ...Stage) *Bar {
+	return &Bar{
+		logger: l,
+		Width:  defaultBarWidth,
+		title:  title,
+		stages: stages,
+		start:  l.now()}
+}
+
+// NewIndeterminateBar returns progress bar of operation `title` with unknown amount of work. Bar is redrawn
+// periodically until Finish or Fail is called.
+func (l *Logger) NewIndeterminateBar(title string) *Bar {
+	b := &Bar{
+		logger:        l,
+		Width:         defaultBarWidth,
+		title:         title,
+		stages:        []Stage{{Name: title, Weight: 1}},
+		start:         l.now(),
+		indeterminate: true,
+		stop:          make(chan struct{})}
+
+	go b.animate()
+
+	return b
+}
+
+// animate redraws indeterminate bar until it is stopped.
+func (b *Bar) animate() {
+	ticker := time.NewTicker(barFrameInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.mu.Lock()
+			if b.finished {
+				b.mu.Unlock()
+				return
+			}
+			b.frame++
+			b.draw()
+			b.mu.Unlock()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// complete marks bar as finished and stops redrawing. It returns false if bar is already finished and
+// completion callback otherwise.
+func (b *Bar) complete() (func(err error), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.finished {
+		return nil, false
+	}
+
+	b.finished = true
+	if b.stop != nil {
+		close(b.stop)
+	}
+
+	return b.onComplete, true
+}
+
+// Stage switches bar to stage `name` with `total` units of work. Previous stages are considered finished.
+// Stages not declared on creation are appended with zero weight. It does nothing after Finish or Fail.
+func (b *Bar) Stage(name string, total float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.finished {
+		return
+	}
+
+	i := b.stage
+	for i < len(b.stages) && b.stages[i].Name != name {
+		i++
+	}
+	if i == len(b.stages) {
+		b.stages = append(b.stages, Stage{Name: name})
+	}
+
+	for ; b.stage < i; b.stage++ {
+		b.done += b.stages[b.stage].Weight
+	}
+
+	b.current, b.total = 0, total
+	b.draw()
+}
+
+// Set sets completed units of work of current stage. It does nothing after Finish or Fail.
+func (b *Bar) Set(current float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.finished {
+		return
+	}
+
+	b.current = current
+	b.draw()
+}
+
+// Add adds `delta` completed units of work of current stage. It does nothing after Finish or Fail.
+func (b *Bar) Add(delta float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.finished {
+		return
+	}
+
+	b.current += delta
+	b.draw()
+}
+
+// draw writes progress message of bar. Message is written with locked mutex, so it cannot follow final
+// message of Finish or Fail.
+func (b *Bar) draw() {
+	b.logger.Progressf("%s", b.render())
+}
+
+// Finish writes Info message about finished operation which replaces bar. Repeated calls of Finish and Fail
+// do nothing.
+func (b *Bar) Finish() (n int, err error) {
+	f, ok := b.complete()
+	if !ok {
+		return 0, nil
+	}
+
+	n, err = b.logger.p(LogLevelInfo, strings.TrimSpace(fmt.Sprintf("%s finished in %s", b.title, b.logger.now().Sub(b.start).Round(time.Millisecond))))
+
+	if f != nil {
+		f(nil)
+	}
+
+	return n, err
+}
+
+// Fail writes Error message about failed operation which replaces bar. Repeated calls of Finish and Fail do
+// nothing.
+func (b *Bar) Fail(err error) (n int, _ error) {
+	f, ok := b.complete()
+	if !ok {
+		return 0, nil
+	}
+
+	n, werr := b.logger.p(LogLevelError, strings.TrimSpace(fmt.Sprintf("%s failed: %v", b.title, err)))
+
+	if f != nil {
+		f(err)
+	}
+
+	return n, werr
+}
+
+// OnComplete sets callback called after Finish with nil error or after Fail with its error.
+func (b *Bar) OnComplete(f func(err error)) {
+	b.mu.Lock()
+	b.onComplete = f
+	b.mu.Unlock()
+}
+
+// IsActive reports whether operation is not finished or failed yet.
+func (b *Bar) IsActive() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return !b.finished
+}
+
+// Current returns completed units of work of current stage.
+func (b *Bar) Current() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.current
+}
+
+// Fraction returns overall completion from 0 to 1. It is always 0 for indeterminate bar.
+func (b *Bar) Fraction() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.indeterminate {
+		return 0
+	}
+
+	return b.fraction()
+}
+
+// StartedAt returns start time of operation.
+func (b *Bar) StartedAt() time.Time {
+	return b.start
+}
+
+// fraction returns overall completion from 0 to 1. Must be called with locked mutex.
+func (b *Bar) fraction() float64 {
+	var weight float64
+	for _, stage := range b.stages {
+		weight += stage.Weight
+	}
+	if weight <= 0 {
+		return 0
+	}
+
+	done := b.done
+	if b.stage < len(b.stages) && b.total > 0 {
+		done += b.stages[b.stage].Weight * min(max(b.current/b.total, 0), 1)
+	}
+
+	return min(done/weight, 1)
+}
+
+// render returns text of progress message. Must be called with locked mutex.
+func (b *Bar) render() string {
+	if b.indeterminate {
+		return b.renderIndeterminate()
+	}
+
+	width := max(b.Width, 0)
+	fraction := b.fraction()
+	filled := int(fraction * float64(width))
+
+	s := fmt.Sprintf("%s [%s%s] %3.0f%%", b.title,
+		strings.Repeat("█", filled), strings.Repeat("░", width-filled), fraction*100)
+
+	if len(b.stages) > 1 && b.stage < len(b.stages) {
+		s += " " + b.stages[b.stage].Name
+	}
+
+	if b.unit != barUnitNone {
+		s += " " + b.renderAmounts()
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// renderAmounts returns completed and total amounts of work, rate and ETA. Must be called with locked mutex.
+func (b *Bar) renderAmounts() string {
+	elapsed := b.logger.now().Sub(b.start).Seconds()
+
+	var rate float64
+	if elapsed > 0 {
+		rate = b.current / elapsed
+	}
+
+	var s string
+	if b.unit == barUnitBytes {
+		s = fmt.Sprintf("%s/%s %s/s", formatBytes(b.current), formatBytes(b.total), formatBytes(rate))
+	} else {
+		s = fmt.Sprintf("%.0f/%.0f items", b.current, b.total)
+	}
+
+	if rate > 0 && b.current < b.total {
+		eta := time.Duration((b.total - b.current) / rate * float64(time.Second))
+		s += " ETA " + eta.Round(time.Second).String()
+	}
+
+	return s
+}
+
+// formatBytes returns humanized amount of bytes `n`, e.g. `1.5 MiB`.
+func formatBytes(n float64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%.0f B", n)
+	}
+
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+
+	i := 0
+	for n /= 1024; n >= 1024 && i < len(units)-1; i++ {
+		n /= 1024
+	}
+
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
+
+// renderIndeterminate returns text of progress message of indeterminate bar. Must be called with locked mutex.
+func (b *Bar) renderIndeterminate() string {
+	width := max(b.Width, 0)
+	segment := min(barSegmentWidth, width)
+
+	// segment moves forth and back
+	pos := 0
+	if steps := width - segment; steps > 0 {
+		pos = b.frame % (2 * steps)
+		if pos > steps {
+			pos = 2*steps - pos
+		}
+	}
+
+	return fmt.Sprintf("%s [%s%s%s] %s", b.title,
+		strings.Repeat("░", pos), strings.Repeat("█", segment), strings.Repeat("░", width-segment-pos),
+		b.logger.now().Sub(b.start).Round(time.Second))
+}
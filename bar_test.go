@@ -0,0 +1,38 @@
+package simplelog
+
+import (
+	"io"
+	"testing"
+)
+
+func TestBarAfterFinish(t *testing.T) {
+	b := NewLogger(io.Discard).NewBar("copy", 10)
+	b.Set(4)
+	b.Finish()
+
+	b.Set(8)
+	b.Add(1)
+	b.Stage("verify", 5)
+
+	if got := b.Current(); got != 4 {
+		t.Errorf("current is %v after Finish, want 4", got)
+	}
+	if got := b.Fraction(); got != 0.4 {
+		t.Errorf("fraction is %v after Finish, want 0.4", got)
+	}
+}
+
+func TestBarNegativeWidth(t *testing.T) {
+	l := NewLogger(io.Discard)
+
+	for _, b := range []*Bar{l.NewBar("copy", 10), l.NewStagedBar("copy"), l.NewIndeterminateBar("copy")} {
+		b.Width = -5
+		b.Set(5)
+
+		b.mu.Lock()
+		b.render()
+		b.mu.Unlock()
+
+		b.Finish()
+	}
+}
@@ -0,0 +1,416 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrQueueFull is returned by BatchSink when its queue is full and entry is dropped
+var ErrQueueFull = errors.New("log queue is full")
+
+// ErrSinkClosed is returned by BatchSink after it is closed
+var ErrSinkClosed = errors.New("log sink is closed")
+
+// BatchOptions defines batching and retry behavior of BatchSink. Zero values are replaced by defaults.
+type BatchOptions struct {
+	// Size is a maximum number of entries in batch
+	Size int
+
+	// Interval is a maximum time entry waits in queue before batch is flushed
+	Interval time.Duration
+
+	// QueueSize is a maximum number of queued entries. New entries are dropped when queue is full.
+	QueueSize int
+
+	// MaxRetries is a maximum number of retries of failed flush. Negative value disables retries. Retries stop
+	// when sink is closed or its context is cancelled.
+	MaxRetries int
+
+	// RetryDelay is a delay before first retry, it is doubled on each next retry
+	RetryDelay time.Duration
+
+	// HighWaterMark is a number of queued entries at which sink becomes degraded. Sink recovers when queue
+	// shrinks to half of it. Default is 80% of queue size.
+	HighWaterMark int
+
+	// OnBackpressure is called from separate goroutine when sink becomes degraded and when it recovers, so
+	// application can shed load or switch to sampling. Calls are serialized and report latest state.
+	OnBackpressure func(degraded bool)
+
+	// Context of sink. When it is cancelled, sink stops accepting entries, flushes queued ones and stops
+	// background goroutine like Close does. Values of context are passed to flushes but its cancellation
+	// does not abort them.
+	Context context.Context
+}
+
+// withDefaults returns options with zero values replaced by defaults.
+func (o BatchOptions) withDefaults() BatchOptions {
+	if o.Size <= 0 {
+		o.Size = defaultBatchSize
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultBatchInterval
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = defaultBatchQueueSize
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = defaultBatchMaxRetries
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = defaultBatchRetryDelay
+	}
+	if o.HighWaterMark <= 0 || o.HighWaterMark > o.QueueSize {
+		o.HighWaterMark = max(o.QueueSize*8/10, 1)
+	}
+	if o.Context == nil {
+		o.Context = context.Background()
+	}
+
+	return o
+}
+
+// BatchSink is a sink which queues entries and flushes them in batches from background goroutine. It is
+// a base of network sinks. Error and Fatal entries use separate priority lane: they are not dropped because
+// of full queue of entries of lower levels and are flushed in batches by separate goroutine ahead of them,
+// even while delivery of other entries is retried.
+type BatchSink struct {
+	flush   func(ctx context.Context, batch []*Entry) error
+	options BatchOptions
+
+	// call flush on each interval even if batch is empty
+	flushEmpty bool
+
+	queue    chan *Entry
+	priority chan *Entry
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	// mutex serializes flushes of queue and priority lane
+	flushMu sync.Mutex
+
+	errorHandler func(error)
+	closeOnce    sync.Once
+
+	// handler of entries which were not delivered after all retries
+	undelivered func(batch []*Entry, err error)
+
+	// number of entries which were not delivered
+	dropped atomic.Int64
+
+	// is queue above high-water mark
+	degraded atomic.Bool
+
+	// mutex serializes backpressure callbacks, last reported state is protected by it
+	notifyMu sync.Mutex
+	notified bool
+
+	// mutex protects queue from sends after close
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewBatchSink returns new batch sink which passes batches of entries to `flush`.
+func NewBatchSink(flush func(ctx context.Context, batch []*Entry) error, options BatchOptions) *BatchSink {
+	return newBatchSink(flush, options, false)
+}
+
+// newBatchSink returns new batch sink. If `flushEmpty` is true, `flush` is called on each interval even if
+// batch is empty.
+func newBatchSink(flush func(ctx context.Context, batch []*Entry) error, options BatchOptions, flushEmpty bool) *BatchSink {
+	options = options.withDefaults()
+
+	s := &BatchSink{
+		flush:      flush,
+		options:    options,
+		flushEmpty: flushEmpty,
+		queue:      make(chan *Entry, options.QueueSize),
+		priority:   make(chan *Entry, defaultBatchPriorityQueueSize),
+		done:       make(chan struct{})}
+
+	s.wg.Add(2)
+	go s.run()
+	go s.runPriority()
+
+	return s
+}
+
+// SetErrorHandler sets handler of delivery errors.
+func (s *BatchSink) SetErrorHandler(h func(error)) {
+	s.mu.Lock()
+	s.errorHandler = h
+	s.mu.Unlock()
+}
+
+// setUndeliveredHandler sets handler of entries which were not delivered after all retries. Entries kept by
+// sink for later delivery are not passed to it. Handler must not retain batch.
+func (s *BatchSink) setUndeliveredHandler(h func(batch []*Entry, err error)) {
+	s.mu.Lock()
+	s.undelivered = h
+	s.mu.Unlock()
+}
+
+// WriteEntry queues entry. It does not block: ErrQueueFull is returned if queue is full. Error and Fatal
+// entries are put to priority lane; if it is full, WriteEntry waits for space up to 1 second before
+// returning ErrQueueFull, so logging goroutines are not blocked by stalled delivery.
+func (s *BatchSink) WriteEntry(e *Entry) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrSinkClosed
+	}
+
+	if e.Level >= LogLevelError && e.Level != LogLevelProgress {
+		select {
+		case s.priority <- e:
+			return nil
+		default:
+		}
+
+		timer := time.NewTimer(defaultBatchPriorityWait)
+		defer timer.Stop()
+
+		select {
+		case s.priority <- e:
+			return nil
+		case <-timer.C:
+			return ErrQueueFull
+		case <-s.options.Context.Done():
+			return ErrSinkClosed
+		}
+	}
+
+	select {
+	case s.queue <- e:
+	default:
+		s.setDegraded(true)
+		return ErrQueueFull
+	}
+
+	if len(s.queue) >= s.options.HighWaterMark {
+		s.setDegraded(true)
+	}
+
+	return nil
+}
+
+// Degraded reports whether queue of sink is above high-water mark and it has not recovered yet.
+func (s *BatchSink) Degraded() bool {
+	return s.degraded.Load()
+}
+
+// setDegraded sets degraded state of sink and calls backpressure callback if state is changed.
+func (s *BatchSink) setDegraded(degraded bool) {
+	if s.degraded.Swap(degraded) == degraded || s.options.OnBackpressure == nil {
+		return
+	}
+
+	go s.notify()
+}
+
+// notify calls backpressure callback with current state if it differs from last reported one.
+func (s *BatchSink) notify() {
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+
+	degraded := s.degraded.Load()
+	if degraded == s.notified {
+		return
+	}
+	s.notified = degraded
+
+	s.options.OnBackpressure(degraded)
+}
+
+// Close flushes queued entries and stops background goroutine.
+func (s *BatchSink) Close() error {
+	s.closeOnce.Do(func() {
+		s.markClosed()
+		close(s.done)
+	})
+	s.wg.Wait()
+
+	return nil
+}
+
+// markClosed stops accepting of new entries.
+func (s *BatchSink) markClosed() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+}
+
+// run collects batches and flushes them.
+func (s *BatchSink) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.options.Interval)
+	defer ticker.Stop()
+
+	batch := make([]*Entry, 0, s.options.Size)
+	for {
+		select {
+		case e := <-s.queue:
+			batch = append(batch, e)
+			if s.degraded.Load() && len(s.queue) <= s.options.HighWaterMark/2 {
+				s.setDegraded(false)
+			}
+			if len(batch) >= s.options.Size {
+				s.send(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 || s.flushEmpty {
+				s.send(batch)
+				batch = batch[:0]
+			}
+		case <-s.options.Context.Done():
+			s.markClosed()
+			s.drain(batch)
+			return
+		case <-s.done:
+			s.drain(batch)
+			return
+		}
+	}
+}
+
+// runPriority flushes entries of priority lane as soon as they are queued, collecting entries queued during
+// previous flush to single batch.
+func (s *BatchSink) runPriority() {
+	defer s.wg.Done()
+
+	batch := make([]*Entry, 0, s.options.Size)
+	for {
+		select {
+		case e := <-s.priority:
+			batch = append(batch[:0], e)
+			for len(batch) < s.options.Size && len(s.priority) > 0 {
+				batch = append(batch, <-s.priority)
+			}
+			s.send(batch)
+		case <-s.options.Context.Done():
+			s.markClosed()
+			s.drainPriority(batch[:0])
+			return
+		case <-s.done:
+			s.drainPriority(batch[:0])
+			return
+		}
+	}
+}
+
+// drainPriority flushes all entries of priority lane. Must be called after sink is closed.
+func (s *BatchSink) drainPriority(batch []*Entry) {
+	// no entries are queued after close
+	for len(s.priority) > 0 {
+		batch = append(batch, <-s.priority)
+		if len(batch) >= s.options.Size || len(s.priority) == 0 {
+			s.send(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// drain flushes batch and all queued entries. Must be called after sink is closed.
+func (s *BatchSink) drain(batch []*Entry) {
+	// no entries are queued after close
+	for len(s.queue) > 0 {
+		batch = append(batch, <-s.queue)
+		if len(batch) >= s.options.Size {
+			s.send(batch)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 || s.flushEmpty {
+		s.send(batch)
+	}
+}
+
+// send flushes batch retrying on errors. Entries delivered by failed flush are not retried.
+func (s *BatchSink) send(batch []*Entry) {
+	delay := s.options.RetryDelay
+	undelivered := batch
+
+	var err error
+	var d *droppedError
+	for attempt := 0; ; attempt++ {
+		s.flushMu.Lock()
+		err = s.flush(context.WithoutCancel(s.options.Context), undelivered)
+		s.flushMu.Unlock()
+		if err == nil {
+			return
+		}
+
+		if errors.As(err, &d) && d.entries != nil {
+			undelivered = d.entries
+		}
+
+		if attempt >= s.options.MaxRetries || !s.wait(delay) {
+			break
+		}
+		delay *= 2
+	}
+
+	if errors.As(err, &d) {
+		s.dropped.Add(int64(d.n))
+		undelivered = d.entries
+	} else {
+		s.dropped.Add(int64(len(undelivered)))
+	}
+
+	s.mu.RLock()
+	h, u := s.errorHandler, s.undelivered
+	s.mu.RUnlock()
+
+	if h != nil {
+		h(fmt.Errorf("deliver %d log messages: %w", len(batch), err))
+	}
+	if u != nil && len(undelivered) > 0 {
+		u(undelivered, err)
+	}
+}
+
+// wait waits for `delay` and reports whether it elapsed before sink was closed or its context was cancelled,
+// so retries do not delay shutdown.
+func (s *BatchSink) wait(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-s.done:
+		return false
+	case <-s.options.Context.Done():
+		return false
+	}
+}
+
+// Dropped returns number of entries which were not delivered after all retries.
+func (s *BatchSink) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// droppedError is a flush error reporting number of dropped entries when it differs from batch size
+type droppedError struct {
+	n int
+
+	// dropped entries if they are known, only they are retried
+	entries []*Entry
+
+	err error
+}
+
+func (e *droppedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *droppedError) Unwrap() error {
+	return e.err
+}
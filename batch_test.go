@@ -0,0 +1,68 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchSinkCloseInterruptsRetries(t *testing.T) {
+	var attempts atomic.Int32
+	flush := func(ctx context.Context, batch []*Entry) error {
+		attempts.Add(1)
+		return errors.New("service unavailable")
+	}
+
+	s := NewBatchSink(flush, BatchOptions{Size: 1, Interval: time.Hour, MaxRetries: 5, RetryDelay: time.Hour})
+	if err := s.WriteEntry(&Entry{Time: time.Now(), Level: LogLevelInfo, Message: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	for attempts.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		s.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("close waits for retry delay")
+	}
+	if got := s.Dropped(); got != 1 {
+		t.Errorf("dropped %d entries, want 1", got)
+	}
+}
+
+func TestBatchSinkContextInterruptsRetries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var attempts atomic.Int32
+	flush := func(ctx context.Context, batch []*Entry) error {
+		attempts.Add(1)
+		return errors.New("service unavailable")
+	}
+
+	s := NewBatchSink(flush, BatchOptions{Size: 1, Interval: time.Hour, MaxRetries: 5, RetryDelay: time.Hour, Context: ctx})
+	if err := s.WriteEntry(&Entry{Time: time.Now(), Level: LogLevelInfo, Message: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	for attempts.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for s.Dropped() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := s.Dropped(); got != 1 {
+		t.Errorf("dropped %d entries after cancellation, want 1", got)
+	}
+	s.Close()
+}
@@ -0,0 +1,55 @@
+package simplelog
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// BytesEncoding defines how []byte field values are encoded
+type BytesEncoding int
+
+const (
+	// BytesHex encodes bytes as hex string
+	BytesHex BytesEncoding = iota
+
+	// BytesBase64 encodes bytes as standard base64 string
+	BytesBase64
+)
+
+// encodeBytes returns bytes `b` encoded by `encoding`. If `maxBytes` is positive, only first `maxBytes`
+// bytes are encoded followed by `...(N bytes)` with total length.
+func encodeBytes(b []byte, encoding BytesEncoding, maxBytes int) string {
+	suffix := ""
+	if maxBytes > 0 && len(b) > maxBytes {
+		suffix = fmt.Sprintf("...(%d bytes)", len(b))
+		b = b[:maxBytes]
+	}
+
+	if encoding == BytesBase64 {
+		return base64.StdEncoding.EncodeToString(b) + suffix
+	}
+
+	return hex.EncodeToString(b) + suffix
+}
+
+// bytesFields returns fields with []byte values replaced by strings encoded by encodeBytes. Fields are copied
+// only if they contain such values.
+func bytesFields(fields []Field, encoding BytesEncoding, maxBytes int) []Field {
+	result := fields
+	copied := false
+	for i, f := range fields {
+		b, ok := f.Value.([]byte)
+		if !ok {
+			continue
+		}
+
+		if !copied {
+			result = append([]Field(nil), fields...)
+			copied = true
+		}
+		result[i].Value = encodeBytes(b, encoding, maxBytes)
+	}
+
+	return result
+}
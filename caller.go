@@ -0,0 +1,84 @@
+package simplelog
+
+import (
+	"bytes"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// CallerTag defines how messages are tagged by caller info
+type CallerTag int
+
+const (
+	// CallerTagNone disables caller tagging
+	CallerTagNone CallerTag = iota
+
+	// CallerTagPackage tags messages with name of package which emitted them
+	CallerTagPackage
+
+	// CallerTagGoroutine tags messages with ID of goroutine which emitted them
+	CallerTagGoroutine
+)
+
+// packagePrefix is a prefix of function names of this package
+const packagePrefix = "github.com/nxshock/simplelog."
+
+// caller returns first stack frame outside of this package.
+func caller() runtime.Frame {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			return frame
+		}
+		if !more {
+			return frame
+		}
+	}
+}
+
+// packageName returns short package name of function `funcName`.
+func packageName(funcName string) string {
+	dir, name := path.Split(funcName)
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+
+	if name == "" {
+		return path.Base(dir)
+	}
+
+	return name
+}
+
+// goroutineID returns ID of current goroutine.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+
+	return id
+}
+
+// callerTag returns tag for message according to caller tag mode `mode`.
+func callerTag(mode CallerTag) string {
+	switch mode {
+	case CallerTagPackage:
+		return packageName(caller().Function)
+	case CallerTagGoroutine:
+		return "g" + strconv.FormatUint(goroutineID(), 10)
+	}
+
+	return ""
+}
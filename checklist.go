@@ -0,0 +1,174 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StepStatus is a status of checklist step
+type StepStatus int
+
+const (
+	StepPending StepStatus = iota
+	StepRunning
+	StepDone
+	StepFailed
+)
+
+// String returns name of step status.
+func (s StepStatus) String() string {
+	switch s {
+	case StepPending:
+		return "pending"
+	case StepRunning:
+		return "running"
+	case StepDone:
+		return "done"
+	case StepFailed:
+		return "failed"
+	}
+
+	return fmt.Sprintf("status(%d)", int(s))
+}
+
+// stepIcons contains terminal icons of step statuses
+var stepIcons = map[StepStatus]string{
+	StepPending: "○",
+	StepRunning: "◐",
+	StepDone:    "✓",
+	StepFailed:  "✗",
+}
+
+// stepStyles contains terminal styles of step statuses
+var stepStyles = map[StepStatus]lipgloss.Style{
+	StepPending: lipgloss.NewStyle().Foreground(lipgloss.Color("#808080")),
+	StepRunning: lipgloss.NewStyle().Foreground(lipgloss.Color("#ffff80")),
+	StepDone:    lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00")),
+	StepFailed:  lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000")),
+}
+
+// Checklist shows status of ordered steps. On terminal all steps are re-rendered in place with status icons
+// on each change; other outputs, and terminal when progress is disabled by NoProgress or quiet mode, get
+// separate message per status change. Steps not declared on creation are
+// appended on first status change.
+type Checklist struct {
+	logger *Logger
+
+	steps    []string
+	statuses []StepStatus
+	notes    []string
+
+	// number of rendered lines and number of bytes written by logger after last rendering, used to detect
+	// messages written below checklist
+	renderedLines int
+	renderedBytes int64
+}
+
+// NewChecklist returns checklist of steps `steps`. Checklist is rendered when first step status is changed.
+func (l *Logger) NewChecklist(steps ...string) *Checklist {
+	return &Checklist{
+		logger:   l,
+		steps:    steps,
+		statuses: make([]StepStatus, len(steps)),
+		notes:    make([]string, len(steps))}
+}
+
+// Start marks step `name` as running.
+func (c *Checklist) Start(name string) {
+	c.set(name, StepRunning, "")
+}
+
+// Done marks step `name` as done.
+func (c *Checklist) Done(name string) {
+	c.set(name, StepDone, "")
+}
+
+// Fail marks step `name` as failed with error `err`.
+func (c *Checklist) Fail(name string, err error) {
+	note := ""
+	if err != nil {
+		note = err.Error()
+	}
+
+	c.set(name, StepFailed, note)
+}
+
+// set changes status of step.
+func (c *Checklist) set(name string, status StepStatus, note string) {
+	l := c.logger
+	l.mu.Lock()
+
+	i := -1
+	for j, step := range c.steps {
+		if step == name {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		c.steps = append(c.steps, name)
+		c.statuses = append(c.statuses, StepPending)
+		c.notes = append(c.notes, "")
+		i = len(c.steps) - 1
+	}
+
+	c.statuses[i] = status
+	c.notes[i] = note
+
+	if !l.progressEnabled() {
+		l.mu.Unlock()
+
+		s := fmt.Sprintf("[%d/%d] %s: %s", i+1, len(c.steps), name, status)
+		if note != "" {
+			s += ": " + note
+		}
+
+		level := LogLevelInfo
+		if status == StepFailed {
+			level = LogLevelError
+		}
+		l.p(level, s)
+		return
+	}
+
+	c.render()
+	l.mu.Unlock()
+}
+
+// render re-renders checklist on terminal. Must be called with locked mutex.
+func (c *Checklist) render() {
+	l := c.logger
+	sb := new(strings.Builder)
+
+	if l.progress.lineWidth > 0 {
+		sb.WriteString("\r\x1b[2K")
+		l.progress.lineWidth = 0
+	}
+
+	// checklist is rendered in place only if nothing was written below it
+	if c.renderedLines > 0 && c.renderedBytes == l.summary.bytes {
+		fmt.Fprintf(sb, "\x1b[%dA\r", c.renderedLines)
+	}
+
+	for i, step := range c.steps {
+		line := stepIcons[c.statuses[i]] + " " + step
+		if c.notes[i] != "" {
+			line += ": " + c.notes[i]
+		}
+		if l.colored() {
+			line = stepStyles[c.statuses[i]].Render(line)
+		}
+
+		sb.WriteString("\x1b[2K")
+		sb.WriteString(line)
+		sb.WriteRune('\n')
+	}
+
+	n, _ := l.writer().Write([]byte(sb.String()))
+	l.summary.bytes += int64(n)
+
+	c.renderedLines = len(c.steps)
+	c.renderedBytes = l.summary.bytes
+}
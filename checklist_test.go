@@ -0,0 +1,40 @@
+package simplelog
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChecklistNoProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(l *Logger)
+	}{
+		{"no progress", func(l *Logger) { l.NoProgress = true }},
+		{"quiet", func(l *Logger) { l.Quiet(true) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			l := NewLogger(buf)
+			l.out.Store(&mainWriter{w: l.Output(), isTerminal: true})
+			l.NoColor = true
+			test.setup(l)
+
+			c := l.NewChecklist("build", "test")
+			c.Start("build")
+			c.Fail("build", errors.New("exit status 1"))
+
+			out := buf.String()
+			if strings.Contains(out, "\x1b[") {
+				t.Errorf("checklist is drawn in place: %q", out)
+			}
+			if !strings.Contains(out, "[1/2] build: failed: exit status 1") {
+				t.Errorf("got output %q", out)
+			}
+		})
+	}
+}
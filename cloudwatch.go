@@ -0,0 +1,147 @@
+package simplelog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+)
+
+const (
+	// maximum number of events in single CloudWatch Logs request
+	cloudWatchMaxEvents = 10000
+
+	// maximum size of single CloudWatch Logs request
+	cloudWatchMaxBatchBytes = 1048576
+
+	// size added to each event in CloudWatch Logs request size limit
+	cloudWatchEventOverhead = 26
+
+	// maximum size of single CloudWatch Logs event including overhead
+	cloudWatchMaxEventBytes = 262144
+
+	// maximum time span of events of single CloudWatch Logs request in milliseconds
+	cloudWatchMaxBatchSpan = 24 * 60 * 60 * 1000
+)
+
+// CloudWatchEvent is a log event of CloudWatch Logs
+type CloudWatchEvent struct {
+	// Time of event in milliseconds since Unix epoch
+	Timestamp int64
+
+	// Message of event
+	Message string
+}
+
+// CloudWatchClient sends log events to CloudWatch Logs. Implementation is usually a thin adapter of
+// PutLogEvents method of AWS SDK client, so package does not depend on SDK.
+type CloudWatchClient interface {
+	// PutLogEvents sends events to log stream `stream` of log group `group` and returns next sequence token.
+	// Sequence token may be empty if it is not used.
+	PutLogEvents(ctx context.Context, group, stream string, events []CloudWatchEvent, sequenceToken string) (nextSequenceToken string, err error)
+}
+
+// InvalidSequenceTokenError is implemented by CloudWatchClient errors which report expected sequence token,
+// like InvalidSequenceTokenException and DataAlreadyAcceptedException of AWS SDK
+type InvalidSequenceTokenError interface {
+	error
+
+	ExpectedSequenceToken() string
+}
+
+// CloudWatchSink is a sink which sends batches of entries to CloudWatch Logs
+type CloudWatchSink struct {
+	*BatchSink
+
+	// Encoder of event messages. It must not be changed after first entry is written.
+	Encoder Encoder
+
+	client CloudWatchClient
+	group  string
+	stream string
+
+	// last sequence token
+	token string
+}
+
+// NewCloudWatchSink returns new sink which sends entries as JSON messages to log stream `stream` of log
+// group `group` using client `client`.
+func NewCloudWatchSink(client CloudWatchClient, group, stream string, options BatchOptions) *CloudWatchSink {
+	s := &CloudWatchSink{
+		Encoder: NewJSONEncoder(),
+		client:  client,
+		group:   group,
+		stream:  stream}
+
+	s.BatchSink = NewBatchSink(s.flush, options)
+
+	return s
+}
+
+// flush sends batch of entries splitting it to requests which fit CloudWatch Logs limits. Messages longer
+// than event size limit are truncated. If request fails, entries sent by previous requests are not retried.
+func (s *CloudWatchSink) flush(ctx context.Context, batch []*Entry) error {
+	// events of single request must be in chronological order, sorting is stable so retried batch keeps order
+	slices.SortStableFunc(batch, func(a, b *Entry) int { return a.Time.Compare(b.Time) })
+
+	events := make([]CloudWatchEvent, 0, len(batch))
+	for _, e := range batch {
+		b, err := s.Encoder.Encode(e)
+		if err != nil {
+			return err
+		}
+
+		message := string(bytes.TrimSuffix(b, []byte{'\n'}))
+		if len(message)+cloudWatchEventOverhead > cloudWatchMaxEventBytes {
+			message = message[:cutIndex(message, cloudWatchMaxEventBytes-cloudWatchEventOverhead-len(defaultTrimMarker))] + defaultTrimMarker
+		}
+
+		events = append(events, CloudWatchEvent{
+			Timestamp: e.Time.UnixMilli(),
+			Message:   message})
+	}
+
+	sent := 0
+	for len(events) > 0 {
+		n, size := 0, 0
+		for n < len(events) && n < cloudWatchMaxEvents {
+			eventSize := len(events[n].Message) + cloudWatchEventOverhead
+			if n > 0 && (size+eventSize > cloudWatchMaxBatchBytes || events[n].Timestamp-events[0].Timestamp >= cloudWatchMaxBatchSpan) {
+				break
+			}
+			size += eventSize
+			n++
+		}
+
+		if err := s.put(ctx, events[:n]); err != nil {
+			if sent > 0 {
+				// first entries of batch are delivered by previous requests
+				return &droppedError{n: len(batch) - sent, entries: batch[sent:], err: err}
+			}
+			return err
+		}
+
+		events = events[n:]
+		sent += n
+	}
+
+	return nil
+}
+
+// put sends single request updating sequence token.
+func (s *CloudWatchSink) put(ctx context.Context, events []CloudWatchEvent) error {
+	token, err := s.client.PutLogEvents(ctx, s.group, s.stream, events, s.token)
+
+	var tokenErr InvalidSequenceTokenError
+	if errors.As(err, &tokenErr) {
+		s.token = tokenErr.ExpectedSequenceToken()
+		token, err = s.client.PutLogEvents(ctx, s.group, s.stream, events, s.token)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.token = token
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// cloudWatchClient is a client which accepts `n` requests and rejects next ones. If `rejects` is positive,
+// only that number of requests is rejected.
+type cloudWatchClient struct {
+	n       int
+	rejects int
+
+	events   []CloudWatchEvent
+	requests int
+}
+
+func (c *cloudWatchClient) PutLogEvents(ctx context.Context, group, stream string, events []CloudWatchEvent, sequenceToken string) (nextSequenceToken string, err error) {
+	if c.n == 0 {
+		if c.rejects == 0 {
+			return "", errors.New("service unavailable")
+		}
+		if c.rejects--; c.rejects == 0 {
+			c.n = -1
+		}
+		return "", errors.New("service unavailable")
+	}
+	c.n--
+	c.requests++
+	c.events = append(c.events, events...)
+
+	return "", nil
+}
+
+func TestCloudWatchPartlySentBatch(t *testing.T) {
+	client := &cloudWatchClient{n: 1}
+	s := NewCloudWatchSink(client, "group", "stream", BatchOptions{Size: 5, Interval: time.Hour, MaxRetries: -1})
+
+	var undelivered []string
+	s.setUndeliveredHandler(func(batch []*Entry, err error) {
+		for _, e := range batch {
+			undelivered = append(undelivered, e.Message)
+		}
+	})
+
+	// each message fills quarter of request, so batch is sent by two requests
+	now := time.Now()
+	for i, message := range []string{"a", "b", "c", "d", "e"} {
+		message += strings.Repeat(" ", cloudWatchMaxBatchBytes/5)
+		if err := s.WriteEntry(&Entry{Time: now.Add(time.Duration(i)), Level: LogLevelInfo, Message: message}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.events) != 4 {
+		t.Errorf("sent %d events, want 4", len(client.events))
+	}
+	if got := s.Dropped(); got != 1 {
+		t.Errorf("dropped %d entries, want 1", got)
+	}
+	if len(undelivered) != 1 || !strings.HasPrefix(undelivered[0], "e") {
+		t.Errorf("undelivered entries are not reported")
+	}
+}
+
+func TestCloudWatchRetryPartlySentBatch(t *testing.T) {
+	client := &cloudWatchClient{n: 1, rejects: 1}
+	s := NewCloudWatchSink(client, "group", "stream", BatchOptions{Size: 5, Interval: time.Hour, MaxRetries: 1, RetryDelay: time.Millisecond})
+
+	now := time.Now()
+	for i, message := range []string{"a", "b", "c", "d", "e"} {
+		message += strings.Repeat(" ", cloudWatchMaxBatchBytes/5)
+		if err := s.WriteEntry(&Entry{Time: now.Add(time.Duration(i)), Level: LogLevelInfo, Message: message}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// priority batch flushed between attempts does not affect retried one
+	if err := s.WriteEntry(&Entry{Time: now, Level: LogLevelError, Message: "d"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// failed flush is not retried after close
+	time.Sleep(50 * time.Millisecond)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.events) != 6 {
+		t.Errorf("sent %d events, want 6", len(client.events))
+	}
+	if got := s.Dropped(); got != 0 {
+		t.Errorf("dropped %d entries, want 0", got)
+	}
+}
+
+func TestCloudWatchLimits(t *testing.T) {
+	client := &cloudWatchClient{n: -1}
+	s := NewCloudWatchSink(client, "group", "stream", BatchOptions{Size: 3, Interval: time.Hour})
+
+	now := time.Now()
+	entries := []*Entry{
+		{Time: now, Level: LogLevelInfo, Message: strings.Repeat("a", cloudWatchMaxEventBytes)},
+		{Time: now.Add(time.Hour), Level: LogLevelInfo, Message: "b"},
+		{Time: now.Add(25 * time.Hour), Level: LogLevelInfo, Message: "c"},
+	}
+	for _, e := range entries {
+		if err := s.WriteEntry(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if client.requests != 2 {
+		t.Errorf("sent %d requests, want 2", client.requests)
+	}
+	if len(client.events) != 3 {
+		t.Fatalf("sent %d events, want 3", len(client.events))
+	}
+	if size := len(client.events[0].Message) + cloudWatchEventOverhead; size > cloudWatchMaxEventBytes {
+		t.Errorf("sent event of %d bytes", size)
+	}
+}
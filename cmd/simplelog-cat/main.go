@@ -0,0 +1,42 @@
+// Command simplelog-cat prints log files written by simplelog with terminal colors.
+//
+// Usage:
+//
+//	simplelog-cat [file ...]
+//
+// Standard input is read if no files are specified.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nxshock/simplelog"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		if err := simplelog.Recolor(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	for _, path := range os.Args[1:] {
+		if err := cat(path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func cat(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return simplelog.Recolor(f, os.Stdout)
+}
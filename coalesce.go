@@ -0,0 +1,140 @@
+package simplelog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// CoalescingWriter is a writer which coalesces writes made within short window into single write to
+// underlying writer, reducing number of syscalls when bursts of messages are logged to files. Data is
+// written when window expires, buffer reaches MaxSize or on Sync and Close.
+type CoalescingWriter struct {
+	// Window is a maximum time data waits in buffer
+	Window time.Duration
+
+	// MaxSize is a size of buffered data after which it is written immediately
+	MaxSize int
+
+	w     io.Writer
+	buf   []byte
+	timer *time.Timer
+
+	// handler of errors of writes made after window expiration
+	errorHandler func(err error)
+
+	// is writer closed by Close
+	closed bool
+
+	mu sync.Mutex
+}
+
+// NewCoalescingWriter returns new writer which coalesces writes to `w` made within 10 ms window.
+func NewCoalescingWriter(w io.Writer) *CoalescingWriter {
+	return &CoalescingWriter{
+		Window:  defaultCoalesceWindow,
+		MaxSize: defaultCoalesceSize,
+		w:       w}
+}
+
+// SetErrorHandler sets handler of errors of writes made after window expiration.
+func (cw *CoalescingWriter) SetErrorHandler(h func(error)) {
+	cw.mu.Lock()
+	cw.errorHandler = h
+	cw.mu.Unlock()
+}
+
+// Write buffers `p`. Error of previous buffered write is not returned, it is passed to error handler. It
+// returns os.ErrClosed after Close.
+func (cw *CoalescingWriter) Write(p []byte) (n int, err error) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	if cw.closed {
+		return 0, os.ErrClosed
+	}
+
+	cw.buf = append(cw.buf, p...)
+
+	if len(cw.buf) >= cw.MaxSize {
+		if err := cw.flush(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if cw.timer == nil {
+		cw.timer = time.AfterFunc(cw.Window, cw.flushTimer)
+	}
+
+	return len(p), nil
+}
+
+// Flush writes buffered data to underlying writer.
+func (cw *CoalescingWriter) Flush() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	return cw.flush()
+}
+
+// Sync writes buffered data and syncs underlying writer if it supports syncing.
+func (cw *CoalescingWriter) Sync() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	if err := cw.flush(); err != nil {
+		return err
+	}
+
+	return syncWriter(cw.w)
+}
+
+// Close writes buffered data and closes underlying writer if it is io.Closer.
+func (cw *CoalescingWriter) Close() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	cw.closed = true
+	err := cw.flush()
+
+	if c, ok := cw.w.(io.Closer); ok {
+		if closeErr := c.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// flushTimer writes buffered data after window expiration.
+func (cw *CoalescingWriter) flushTimer() {
+	cw.mu.Lock()
+	err := cw.flush()
+	h := cw.errorHandler
+	cw.mu.Unlock()
+
+	// handler is called without lock, it may log through this writer
+	if err != nil && h != nil {
+		h(fmt.Errorf("write log messages: %w", err))
+	}
+}
+
+// flush writes buffered data. Must be called with locked mutex.
+func (cw *CoalescingWriter) flush() error {
+	if cw.timer != nil {
+		cw.timer.Stop()
+		cw.timer = nil
+	}
+
+	if len(cw.buf) == 0 {
+		return nil
+	}
+
+	_, err := cw.w.Write(cw.buf)
+	cw.buf = cw.buf[:0]
+
+	return err
+}
@@ -0,0 +1,55 @@
+package simplelog
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCoalescingWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cw := NewCoalescingWriter(buf)
+	cw.Window = time.Hour
+
+	cw.Write([]byte("a"))
+	cw.Write([]byte("b"))
+	if buf.Len() != 0 {
+		t.Errorf("written %q before window expiration", buf)
+	}
+
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "ab" {
+		t.Errorf("written %q, want %q", buf, "ab")
+	}
+
+	if _, err := cw.Write([]byte("c")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("got error %v after close, want %v", err, os.ErrClosed)
+	}
+}
+
+func TestCoalescingWriterErrorHandler(t *testing.T) {
+	w := &failingWriter{fail: true}
+	cw := NewCoalescingWriter(w)
+	cw.Window = time.Millisecond
+
+	errs := make(chan error, 1)
+	if _, err := cw.Write([]byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	// handler is set while flush may be running
+	cw.SetErrorHandler(func(err error) { errs <- err })
+	cw.Write([]byte("b"))
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("handler is called without error")
+		}
+	case <-time.After(time.Second):
+		t.Error("error of delayed write is not handled")
+	}
+}
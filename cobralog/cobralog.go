@@ -0,0 +1,49 @@
+// Package cobralog wires simplelog logger into cobra commands.
+package cobralog
+
+import (
+	"flag"
+
+	"github.com/nxshock/simplelog"
+	"github.com/spf13/cobra"
+)
+
+// Setup registers persistent logging flags of command `cmd` and installs PersistentPreRunE initializer which
+// applies them to logger `l`. Existing PersistentPreRun/PersistentPreRunE of command is called after initializer.
+//
+// Registered flags are --log-level, --log-format, --log-file, --no-color, -q/--quiet and -v/--verbose
+// (may be repeated). Quiet flag enables quiet mode of logger which also disables progress messages. Quiet and
+// verbose flags are ignored if --log-level is set, so explicit level is not changed silently.
+func Setup(cmd *cobra.Command, l *simplelog.Logger) {
+	gfs := flag.NewFlagSet(cmd.Name(), flag.ContinueOnError)
+	l.RegisterFlags(gfs)
+
+	fs := cmd.PersistentFlags()
+	for _, name := range []string{"log-level", "log-format", "log-file", "no-color"} {
+		fs.AddGoFlag(gfs.Lookup(name))
+	}
+
+	quiet := fs.BoolP("quiet", "q", false, "print only warnings and errors")
+	verbose := fs.CountP("verbose", "v", "print debug messages, repeat to print trace messages")
+
+	preRunE, preRun := cmd.PersistentPreRunE, cmd.PersistentPreRun
+	cmd.PersistentPreRun = nil
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		switch {
+		case cmd.Flags().Changed("log-level"):
+		case *quiet:
+			l.Quiet(true)
+		case *verbose > 0:
+			l.SetVerbosity(*verbose)
+		}
+
+		if preRunE != nil {
+			return preRunE(cmd, args)
+		}
+		if preRun != nil {
+			preRun(cmd, args)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+package cobralog
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nxshock/simplelog"
+	"github.com/spf13/cobra"
+)
+
+func TestSetupLevel(t *testing.T) {
+	tests := []struct {
+		args []string
+		want simplelog.LogLevel
+	}{
+		{nil, simplelog.LogLevelInfo},
+		{[]string{"-v"}, simplelog.LogLevelDebug},
+		{[]string{"-vv"}, simplelog.LogLevelTrace},
+		{[]string{"-q"}, simplelog.LogLevelWarn},
+		{[]string{"--log-level=warn", "-v"}, simplelog.LogLevelWarn},
+		{[]string{"-v", "--log-level=error"}, simplelog.LogLevelError},
+		{[]string{"--log-level=debug", "-q"}, simplelog.LogLevelDebug},
+		{[]string{"sub", "--log-level=warn", "-v"}, simplelog.LogLevelWarn},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			l := simplelog.NewLogger(io.Discard)
+
+			cmd := &cobra.Command{Use: "app", Run: func(cmd *cobra.Command, args []string) {}}
+			cmd.AddCommand(&cobra.Command{Use: "sub", Run: func(cmd *cobra.Command, args []string) {}})
+			Setup(cmd, l)
+
+			cmd.SetArgs(test.args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if got := l.Level(); got != test.want {
+				t.Errorf("level is %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package simplelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// Config represents declarative logger configuration.
+type Config struct {
+	// Minimum log level of messages of main output and outputs without own level
+	Level LogLevel `json:"level" yaml:"level" toml:"level"`
+
+	// Format of messages written to non-terminal output: `text`, `json` or `container`
+	Format Format `json:"format" yaml:"format" toml:"format"`
+
+	// Output is `stderr`, `stdout` or path of log file. Default is `stderr`.
+	Output string `json:"output" yaml:"output" toml:"output"`
+
+	// Rotation of log file
+	Rotation RotationConfig `json:"rotation" yaml:"rotation" toml:"rotation"`
+
+	// Additional outputs
+	Outputs []OutputConfig `json:"outputs" yaml:"outputs" toml:"outputs"`
+
+	// Minimum log levels of specific packages
+	Modules map[string]LogLevel `json:"modules" yaml:"modules" toml:"modules"`
+
+	// Colors of timestamp (`timestamp` key), fields (`fields` key) and log levels (level names as keys)
+	Theme map[string]string `json:"theme" yaml:"theme" toml:"theme"`
+
+	// Record verbose fields on messages of all levels
+	VerboseFields bool `json:"verbose_fields" yaml:"verbose_fields" toml:"verbose_fields"`
+
+	// Show level as colored gutter at the start of terminal lines instead of coloring message text
+	Gutter bool `json:"gutter" yaml:"gutter" toml:"gutter"`
+
+	// Timestamp format. Default format is used if empty.
+	TimeFormat string `json:"time_format" yaml:"time_format" toml:"time_format"`
+
+	// Strip messages from spaces before output
+	StripMessages bool `json:"strip_messages" yaml:"strip_messages" toml:"strip_messages"`
+
+	// Marker of trimmed messages
+	TrimMarker string `json:"trim_marker" yaml:"trim_marker" toml:"trim_marker"`
+
+	// Minimum period of progress updates, e.g. `100ms`
+	MinProgressUpdatePeriod string `json:"min_progress_update_period" yaml:"min_progress_update_period" toml:"min_progress_update_period"`
+
+	// Caller tagging mode: `package` or `goroutine`
+	CallerTag string `json:"caller_tag" yaml:"caller_tag" toml:"caller_tag"`
+
+	// Record goroutine ID on each message
+	GoroutineID bool `json:"goroutine_id" yaml:"goroutine_id" toml:"goroutine_id"`
+
+	// Record host and process metadata on each message
+	ProcessInfo bool `json:"process_info" yaml:"process_info" toml:"process_info"`
+
+	// Application version recorded with process metadata
+	AppVersion string `json:"app_version" yaml:"app_version" toml:"app_version"`
+}
+
+// RotationConfig represents log file rotation configuration.
+type RotationConfig struct {
+	// Maximum size of log file in megabytes. Zero value disables rotation.
+	MaxSize int64 `json:"max_size" yaml:"max_size" toml:"max_size"`
+
+	// Maximum number of rotated files to keep
+	MaxBackups int `json:"max_backups" yaml:"max_backups" toml:"max_backups"`
+
+	// Symlink to current date-stamped log file. Log file is date-stamped if its path contains `{date}`.
+	Symlink string `json:"symlink" yaml:"symlink" toml:"symlink"`
+
+	// Use UTC dates in names of date-stamped log files
+	UTC bool `json:"utc" yaml:"utc" toml:"utc"`
+
+	// Maximum age of old log files in days
+	MaxAge int `json:"max_age" yaml:"max_age" toml:"max_age"`
+
+	// Maximum total size of old log files in megabytes
+	MaxTotalSize int64 `json:"max_total_size" yaml:"max_total_size" toml:"max_total_size"`
+
+	// Compress old log files with gzip
+	Compress bool `json:"compress" yaml:"compress" toml:"compress"`
+}
+
+// OutputConfig represents additional output configuration.
+type OutputConfig struct {
+	// Output is `stderr`, `stdout` or path of log file
+	Output string `json:"output" yaml:"output" toml:"output"`
+
+	// Format of messages: `text`, `json` or `container`
+	Format Format `json:"format" yaml:"format" toml:"format"`
+
+	// Rotation of log file
+	Rotation RotationConfig `json:"rotation" yaml:"rotation" toml:"rotation"`
+
+	// Minimum log level of output. Level of main output is used if not set.
+	Level *LogLevel `json:"level" yaml:"level" toml:"level"`
+}
+
+// LoadConfig returns logger configured by config file `path`. Format of file is detected by its extension:
+// `.json`, `.yaml`/`.yml` or `.toml`.
+func LoadConfig(path string) (*Logger, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	config := DefaultConfig()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(b, config)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, config)
+	case ".toml":
+		err = toml.Unmarshal(b, config)
+	default:
+		return nil, fmt.Errorf("unknown config format: %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	return config.NewLogger()
+}
+
+// DefaultConfig returns config with default values.
+func DefaultConfig() *Config {
+	return &Config{
+		Level:      defaulLogLevel,
+		Output:     "stderr",
+		TrimMarker: defaultTrimMarker}
+}
+
+// NewLogger returns new logger configured by config.
+func (c *Config) NewLogger() (*Logger, error) {
+	var period time.Duration
+	if c.MinProgressUpdatePeriod != "" {
+		d, err := time.ParseDuration(c.MinProgressUpdatePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("parse min progress update period: %w", err)
+		}
+		period = d
+	}
+
+	var callerTag CallerTag
+	switch c.CallerTag {
+	case "":
+	case "package":
+		callerTag = CallerTagPackage
+	case "goroutine":
+		callerTag = CallerTagGoroutine
+	default:
+		return nil, fmt.Errorf("unknown caller tag mode: %q", c.CallerTag)
+	}
+
+	theme := make(map[string]lipgloss.Style)
+	for key, color := range c.Theme {
+		if key != "timestamp" && key != "fields" {
+			if _, err := ParseLevel(key); err != nil {
+				return nil, fmt.Errorf("parse theme: %w", err)
+			}
+		}
+
+		theme[key] = lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	}
+
+	w, err := openOutput(c.Output, c.Rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := NewLogger(w)
+	logger.SetLevel(c.Level)
+	logger.Format = c.Format
+	logger.Modules = c.Modules
+	logger.StripMessages = c.StripMessages
+	logger.TrimMarker = c.TrimMarker
+	logger.MinProgressUpdatePeriod = period
+	logger.CallerTag = callerTag
+	logger.GoroutineID = c.GoroutineID
+	logger.ProcessInfo = c.ProcessInfo
+	logger.AppVersion = c.AppVersion
+	logger.Gutter = c.Gutter
+	logger.VerboseFields = c.VerboseFields
+
+	if c.TimeFormat != "" {
+		logger.TimeFormat = c.TimeFormat
+	}
+
+	for key, style := range theme {
+		switch key {
+		case "timestamp":
+			logger.TimeStampStyle = style
+		case "fields":
+			logger.FieldStyle = style
+		default:
+			level, _ := ParseLevel(key)
+			logger.Styles[level] = &style
+		}
+	}
+
+	for _, output := range c.Outputs {
+		w, err := openOutput(output.Output, output.Rotation)
+		if err != nil {
+			closeFiles(logger)
+			return nil, err
+		}
+
+		var enc Encoder
+		switch output.Format {
+		case FormatJSON:
+			enc = NewJSONEncoder()
+		case FormatContainer:
+			enc = NewContainerEncoder()
+		default:
+			textEncoder := NewTextEncoder()
+			if c.TimeFormat != "" {
+				textEncoder.TimeFormat = c.TimeFormat
+			}
+			enc = textEncoder
+		}
+
+		logger.AddOutput(enc, w).Level = output.Level
+	}
+
+	return logger, nil
+}
+
+// closeFiles closes log files of main writer and additional outputs of logger `l`.
+func closeFiles(l *Logger) {
+	if file, ok := l.writer().(*File); ok {
+		file.Close()
+	}
+
+	for _, output := range l.Outputs {
+		if file, ok := output.Writer.(*File); ok {
+			file.Close()
+		}
+	}
+}
+
+// openOutput returns writer of output `output`: standard stream or log file.
+func openOutput(output string, rotation RotationConfig) (io.Writer, error) {
+	switch output {
+	case "stderr", "":
+		return os.Stderr, nil
+	case "stdout":
+		return os.Stdout, nil
+	}
+
+	var (
+		file *File
+		err  error
+	)
+	switch {
+	case strings.Contains(output, datePlaceholder) && rotation.UTC:
+		file, err = OpenDailyFileUTC(output, rotation.Symlink)
+	case strings.Contains(output, datePlaceholder):
+		file, err = OpenDailyFile(output, rotation.Symlink)
+	default:
+		file, err = OpenFile(output)
+	}
+	if err != nil {
+		return nil, err
+	}
+	file.MaxSize = rotation.MaxSize * 1024 * 1024
+	file.MaxBackups = rotation.MaxBackups
+	file.SetRetention(Retention{
+		MaxAge:       time.Duration(rotation.MaxAge) * 24 * time.Hour,
+		MaxTotalSize: rotation.MaxTotalSize * 1024 * 1024,
+		Compress:     rotation.Compress})
+
+	return file, nil
+}
@@ -0,0 +1,30 @@
+package simplelog
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigClosesFilesOnError(t *testing.T) {
+	dir := t.TempDir()
+	c := &Config{
+		Output: filepath.Join(dir, "main.log"),
+		Outputs: []OutputConfig{
+			{Output: filepath.Join(dir, "extra.log")},
+			{Output: filepath.Join(dir, "missing", "app.log")},
+		},
+	}
+
+	if _, err := c.NewLogger(); err == nil {
+		t.Fatal("logger is created with unopenable output")
+	}
+
+	openFiles.mu.Lock()
+	defer openFiles.mu.Unlock()
+
+	for file := range openFiles.files {
+		if filepath.Dir(file.Path) == dir {
+			t.Errorf("log file %s is left open", file.Path)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package simplelog
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 type LogLevel int
 
@@ -19,10 +23,35 @@ const (
 	defaultTerminalTimestampFormat = "15:04:05"
 	defaulLogLevel                 = LogLevelInfo
 	defaultTrimMarker              = "..."
+	defaultSyncLevel               = LogLevelFatal
+	defaultProgressLevel           = LogLevelInfo
+	defaultFileDateFormat          = "2006-01-02"
+	datePlaceholder                = "{date}"
+	compressedExt                  = ".gz"
+	defaultRetentionInterval       = time.Hour
+	defaultBatchSize               = 100
+	defaultBatchInterval           = time.Second
+	defaultBatchQueueSize          = 10000
+	defaultBatchPriorityQueueSize  = 1000
+	defaultBatchPriorityWait       = time.Second
+	defaultBatchMaxRetries         = 3
+	defaultBatchRetryDelay         = 100 * time.Millisecond
+	defaultMQTTOfflineBufferSize   = 10000
+	defaultTimeoutQueueSize        = 1000
+	defaultDeferredBufferSize      = 10000
+	defaultCoalesceWindow          = 10 * time.Millisecond
+	defaultCoalesceSize            = 64 * 1024
+	defaultBarWidth                = 20
+	defaultFatalHookTimeout        = 5 * time.Second
+	defaultExitShutdownTimeout     = 5 * time.Second
+	defaultMaxFieldBytes           = 32
+	defaultFailoverRetryInterval   = 10 * time.Second
+	defaultDedupMaxKeys            = 1000
 )
 
 var (
 	defaultTimestampStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
+	defaultFieldStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
 	defaultTraceStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
 	defaultDebugStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
 	// defaultInfoStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#cccccc"))
@@ -31,3 +60,27 @@ var (
 	defaultFatalStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
 	defaultProgressStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
 )
+
+var (
+	defaultDurationStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#5fd7d7"))
+	defaultErrorValueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	defaultURLStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("#5fafff"))
+	defaultPathStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#d7af5f"))
+	defaultTrueStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#5fd75f"))
+	defaultFalseStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff875f"))
+)
+
+// gutterChar is a bar which shows message level in gutter mode
+const gutterChar = "▌"
+
+// tagPalette is a set of colors used for source tags of Mux
+var tagPalette = []lipgloss.Color{
+	lipgloss.Color("#5fafff"),
+	lipgloss.Color("#5fd75f"),
+	lipgloss.Color("#d7af5f"),
+	lipgloss.Color("#d75fd7"),
+	lipgloss.Color("#5fd7d7"),
+	lipgloss.Color("#ff875f"),
+	lipgloss.Color("#af87ff"),
+	lipgloss.Color("#87d787"),
+}
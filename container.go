@@ -0,0 +1,79 @@
+package simplelog
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"time"
+)
+
+// DetectContainer enables automatic selection of FormatContainer by NewLogger for non-terminal standard
+// output and error streams when process is running inside a container. Set it to false before creating
+// loggers to opt out.
+var DetectContainer = true
+
+// ContainerEncoder encodes entries to single-line JSON objects with `time`, `level` and `msg` keys, UTC
+// RFC 3339 timestamps and without ANSI escape sequences, as expected by container log drivers like
+// Docker json-file.
+type ContainerEncoder struct{}
+
+// NewContainerEncoder returns new container encoder.
+func NewContainerEncoder() *ContainerEncoder {
+	return &ContainerEncoder{}
+}
+
+// Encode implements Encoder.
+func (enc *ContainerEncoder) Encode(e *Entry) ([]byte, error) {
+	return (&JSONEncoder{TimeFormat: time.RFC3339Nano, UTC: true, Sanitize: defaultJSONSanitize}).Encode(e)
+}
+
+// formatEncoder returns encoder of main writer or nil for plain text format.
+func (l *Logger) formatEncoder() Encoder {
+	if l.Encoder != nil {
+		return l.Encoder
+	}
+
+	if l.terminal() {
+		return nil
+	}
+
+	switch l.Format {
+	case FormatJSON:
+		// control characters are escaped by JSON
+		return &JSONEncoder{TimeFormat: l.TimeFormat, Sanitize: l.Sanitize &^ (SanitizeCR | SanitizeControl)}
+	case FormatContainer:
+		return NewContainerEncoder()
+	}
+
+	return nil
+}
+
+// inContainer reports whether process is running inside a container. Result is detected once.
+var inContainer = sync.OnceValue(func() bool {
+	if _, exists := os.LookupEnv("KUBERNETES_SERVICE_HOST"); exists {
+		return true
+	}
+
+	if _, exists := os.LookupEnv("container"); exists {
+		return true
+	}
+
+	for _, path := range []string{"/.dockerenv", "/run/.containerenv"} {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+
+	b, err := os.ReadFile("/proc/1/cgroup")
+	if err != nil {
+		return false
+	}
+
+	for _, marker := range []string{"docker", "kubepods", "containerd", "lxc", "podman"} {
+		if bytes.Contains(b, []byte(marker)) {
+			return true
+		}
+	}
+
+	return false
+})
@@ -0,0 +1,65 @@
+package simplelog
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+)
+
+// CaptureCrash makes runtime write unhandled panics and fatal errors to file `path` in addition to stderr.
+// Header line with process metadata is appended to file in log format on each call, so crash reports which
+// follow it can be attributed to process run and time.
+func (l *Logger) CaptureCrash(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open crash file: %w", err)
+	}
+	defer f.Close()
+
+	var fields []Field
+	if !l.deterministic() {
+		fields = processInfo()
+	}
+	if l.AppVersion != "" {
+		fields = append(fields[:len(fields):len(fields)], Field{"version", l.AppVersion})
+	}
+
+	header := &Entry{
+		Time:    l.now(),
+		Level:   LogLevelInfo,
+		Source:  l.name,
+		Message: "crash output captured",
+		Fields:  fields}
+
+	b, err := l.crashEncoder().Encode(header)
+	if err != nil {
+		return fmt.Errorf("encode crash header: %w", err)
+	}
+
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("write crash file: %w", err)
+	}
+
+	// SetCrashOutput duplicates file descriptor, so file can be closed
+	if err := debug.SetCrashOutput(f, debug.CrashOptions{}); err != nil {
+		return fmt.Errorf("set crash output: %w", err)
+	}
+
+	return nil
+}
+
+// crashEncoder returns encoder of crash file header. Format of main writer is used; terminal timestamp
+// format is replaced by file one since crash file is not a terminal.
+func (l *Logger) crashEncoder() Encoder {
+	if enc := l.formatEncoder(); enc != nil {
+		return enc
+	}
+
+	enc := NewTextEncoder()
+	if !l.terminal() {
+		enc.TimeFormat = l.TimeFormat
+	}
+	enc.Sanitize = l.Sanitize
+
+	return enc
+}
@@ -0,0 +1,32 @@
+package simplelog
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+)
+
+func TestCaptureCrashHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash.log")
+
+	l := NewLogger(io.Discard)
+	l.Format = FormatJSON
+	l.DeterministicMode()
+
+	if err := l.CaptureCrash(path); err != nil {
+		t.Fatal(err)
+	}
+	defer debug.SetCrashOutput(nil, debug.CrashOptions{})
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"time":"2000-01-01 00:00:00","level":"info","msg":"crash output captured"}` + "\n"
+	if string(b) != want {
+		t.Errorf("got header %q, want %q", b, want)
+	}
+}
@@ -0,0 +1,127 @@
+package simplelog
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// maxEncryptedRecordSize is a maximum size of encrypted record accepted by Decrypt
+const maxEncryptedRecordSize = 64 * 1024 * 1024
+
+// EncryptedWriter encrypts written data with AES-GCM. Every Write call produces separate record:
+// 4-byte big-endian length of sealed data, random nonce and sealed data. Records may be appended to
+// existing file and decrypted with Decrypt.
+type EncryptedWriter struct {
+	w    io.Writer
+	aead cipher.AEAD
+
+	mu sync.Mutex
+}
+
+// NewEncryptedWriter returns writer which encrypts data with AES key `key` (16, 24 or 32 bytes long) and
+// writes it to `w`.
+func NewEncryptedWriter(w io.Writer, key []byte) (*EncryptedWriter, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedWriter{w: w, aead: aead}, nil
+}
+
+// Write encrypts `p` as single record.
+func (ew *EncryptedWriter) Write(p []byte) (n int, err error) {
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+
+	nonceSize := ew.aead.NonceSize()
+	record := make([]byte, 4+nonceSize, 4+nonceSize+len(p)+ew.aead.Overhead())
+
+	if _, err := rand.Read(record[4:]); err != nil {
+		return 0, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	record = ew.aead.Seal(record, record[4:], p, nil)
+	binary.BigEndian.PutUint32(record, uint32(len(record)-4))
+
+	if _, err := ew.w.Write(record); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Sync syncs underlying writer if it supports syncing.
+func (ew *EncryptedWriter) Sync() error {
+	return syncWriter(ew.w)
+}
+
+// Close closes underlying writer if it is io.Closer.
+func (ew *EncryptedWriter) Close() error {
+	if c, ok := ew.w.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// Decrypt decrypts records written by EncryptedWriter from `r` to `w` using AES key `key`.
+func Decrypt(r io.Reader, w io.Writer, key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(r)
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read encrypted record: %w", err)
+		}
+
+		size := binary.BigEndian.Uint32(header)
+		if size < uint32(aead.NonceSize()+aead.Overhead()) || size > maxEncryptedRecordSize {
+			return fmt.Errorf("invalid encrypted record size: %d", size)
+		}
+
+		record := make([]byte, size)
+		if _, err := io.ReadFull(br, record); err != nil {
+			return fmt.Errorf("read encrypted record: %w", err)
+		}
+
+		nonce, sealed := record[:aead.NonceSize()], record[aead.NonceSize():]
+		plain, err := aead.Open(sealed[:0], nonce, sealed, nil)
+		if err != nil {
+			return fmt.Errorf("decrypt record: %w", err)
+		}
+
+		if _, err := w.Write(plain); err != nil {
+			return err
+		}
+	}
+}
+
+// newAEAD returns AES-GCM cipher with key `key`.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,74 @@
+package simplelog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncryptedWriterRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+
+	buf := new(bytes.Buffer)
+	ew, err := NewEncryptedWriter(buf, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLogger(ew)
+	l.Info("first")
+	l.Warn("second")
+
+	if bytes.Contains(buf.Bytes(), []byte("first")) {
+		t.Error("encrypted output contains plain text")
+	}
+
+	out := new(bytes.Buffer)
+	if err := Decrypt(bytes.NewReader(buf.Bytes()), out, key); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Parse(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Message != "first" || entries[1].Message != "second" {
+		t.Errorf("decrypted entries %v", entries)
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 16)
+
+	buf := new(bytes.Buffer)
+	ew, err := NewEncryptedWriter(buf, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ew.Write([]byte("message\n")); err != nil {
+		t.Fatal(err)
+	}
+	record := buf.Bytes()
+
+	tampered := bytes.Clone(record)
+	tampered[len(tampered)-1] ^= 1
+
+	tests := []struct {
+		name string
+		data []byte
+		key  []byte
+	}{
+		{"wrong key", record, bytes.Repeat([]byte{2}, 16)},
+		{"tampered", tampered, key},
+		{"truncated", record[:len(record)-1], key},
+		{"invalid size", []byte{0xff, 0xff, 0xff, 0xff}, key},
+		{"invalid key", record, []byte("short")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := Decrypt(bytes.NewReader(test.data), new(bytes.Buffer), test.key); err == nil {
+				t.Error("no error")
+			}
+		})
+	}
+}
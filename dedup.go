@@ -0,0 +1,153 @@
+package simplelog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DedupKey defines which messages are considered duplicates
+type DedupKey int
+
+const (
+	// DedupMessage compares exact message text
+	DedupMessage DedupKey = iota
+
+	// DedupTemplate compares message template with numbers replaced, so `retry 1` and `retry 2` are
+	// duplicates
+	DedupTemplate
+
+	// DedupMessageFields compares message text and fields
+	DedupMessageFields
+)
+
+// dedupState holds messages written during current windows, shared by all loggers derived from logger
+type dedupState struct {
+	key    DedupKey
+	window time.Duration
+
+	// first message and number of suppressed duplicates by comparison key
+	seen map[string]*dedupRecord
+
+	mu sync.Mutex
+}
+
+// dedupRecord represents message and number of its suppressed duplicates
+type dedupRecord struct {
+	entry *Entry
+	count int
+
+	// logger which writes number of duplicates and timer which closes window
+	logger *Logger
+	timer  *time.Timer
+}
+
+// Dedup enables suppression of duplicate messages: after message is written, messages of the same level
+// which are equal to it by comparison key `key` are suppressed during `window`. When window closes, message
+// with number of suppressed duplicates is written, e.g. `connection failed (suppressed 5 duplicates)`.
+// Non-positive window disables suppression. Progress messages are never suppressed. At most 1000 distinct
+// messages are tracked at once, other messages are written as is. Open windows are closed by Shutdown.
+func (l *Logger) Dedup(key DedupKey, window time.Duration) {
+	if window <= 0 {
+		l.dedup = nil
+		return
+	}
+
+	l.dedup = &dedupState{key: key, window: window, seen: make(map[string]*dedupRecord)}
+}
+
+// suppress reports whether entry `e` is a duplicate which must not be written. First message of window
+// starts timer which writes number of suppressed duplicates by logger `l` when window closes.
+func (d *dedupState) suppress(l *Logger, e *Entry) bool {
+	if e.Level == LogLevelProgress {
+		return false
+	}
+
+	k := d.keyOf(e)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if r, exists := d.seen[k]; exists {
+		r.count++
+		return true
+	}
+
+	if len(d.seen) >= defaultDedupMaxKeys {
+		return false
+	}
+
+	r := &dedupRecord{entry: e, logger: l}
+	r.timer = time.AfterFunc(d.window, func() { d.expire(k, r) })
+	d.seen[k] = r
+
+	return false
+}
+
+// expire closes window of record `r` with comparison key `k`.
+func (d *dedupState) expire(k string, r *dedupRecord) {
+	d.mu.Lock()
+	if d.seen[k] != r {
+		// window is already closed by flush
+		d.mu.Unlock()
+		return
+	}
+	delete(d.seen, k)
+	d.mu.Unlock()
+
+	r.report()
+}
+
+// flush closes all open windows writing numbers of suppressed duplicates in order of first messages.
+func (d *dedupState) flush() {
+	d.mu.Lock()
+	records := make([]*dedupRecord, 0, len(d.seen))
+	for _, r := range d.seen {
+		r.timer.Stop()
+		records = append(records, r)
+	}
+	clear(d.seen)
+	d.mu.Unlock()
+
+	slices.SortFunc(records, func(a, b *dedupRecord) int { return a.entry.Time.Compare(b.entry.Time) })
+	for _, r := range records {
+		r.report()
+	}
+}
+
+// report writes message with number of suppressed duplicates if there were any.
+func (r *dedupRecord) report() {
+	if r.count == 0 {
+		return
+	}
+
+	// caller and metadata of first message are kept
+	e := *r.entry
+	e.Time = r.logger.now()
+	e.Message = fmt.Sprintf("%s (suppressed %s)", e.Message, plural(r.count, "duplicate"))
+	e.display, e.hidden = "", 0
+
+	r.logger.emit(&e)
+}
+
+// keyOf returns comparison key of entry `e`.
+func (d *dedupState) keyOf(e *Entry) string {
+	sb := new(strings.Builder)
+	fmt.Fprintf(sb, "%d\x00", e.Level)
+
+	switch d.key {
+	case DedupTemplate:
+		sb.WriteString(normalizeTemplate(e.Message))
+	case DedupMessageFields:
+		sb.WriteString(e.Message)
+		for _, f := range e.Fields {
+			fmt.Fprintf(sb, "\x00%s=%v", f.Key, f.Value)
+		}
+	default:
+		sb.WriteString(e.Message)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package simplelog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDedupShutdown(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(buf)
+	l.Dedup(DedupMessage, time.Hour)
+
+	for range 3 {
+		l.Info("connection failed")
+	}
+	l.Info("other")
+
+	if err := l.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if strings.Count(out, "\n") != 3 || !strings.Contains(out, "connection failed (suppressed 2 duplicates)") {
+		t.Errorf("got output:\n%s", out)
+	}
+	if len(l.dedup.seen) != 0 {
+		t.Errorf("%d windows are open after shutdown", len(l.dedup.seen))
+	}
+}
+
+func TestDedupMaxKeys(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(buf)
+	l.Dedup(DedupMessage, time.Hour)
+	defer l.dedup.flush()
+
+	for i := range defaultDedupMaxKeys + 1 {
+		l.Info(fmt.Sprint(i))
+	}
+	// message which is not tracked is not suppressed
+	l.Info(fmt.Sprint(defaultDedupMaxKeys))
+
+	if got := len(l.dedup.seen); got != defaultDedupMaxKeys {
+		t.Errorf("tracked %d messages, want %d", got, defaultDedupMaxKeys)
+	}
+	if got := strings.Count(buf.String(), "\n"); got != defaultDedupMaxKeys+2 {
+		t.Errorf("written %d messages, want %d", got, defaultDedupMaxKeys+2)
+	}
+}
@@ -0,0 +1,82 @@
+package simplelog
+
+import (
+	"io"
+	"sync"
+)
+
+// deferredSink buffers entries until target logger is attached and then forwards them to it
+type deferredSink struct {
+	entries []*Entry
+	dropped int
+	target  *Logger
+
+	mu sync.Mutex
+}
+
+// Deferred returns logger which buffers messages of all levels in memory until real logger is attached by
+// Attach, e.g. before command line flags and config are parsed. Up to 10000 last messages are buffered.
+func Deferred() *Logger {
+	l := NewLogger(io.Discard)
+	l.SetLevel(LogLevelTrace)
+	l.NoProgress = true
+	l.deferred = new(deferredSink)
+	l.AddSink(l.deferred)
+
+	return l
+}
+
+// WriteEntry implements Sink.
+func (s *deferredSink) WriteEntry(e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.target != nil {
+		return s.target.forward(e)
+	}
+
+	if len(s.entries) >= defaultDeferredBufferSize {
+		s.entries = s.entries[1:]
+		s.dropped++
+	}
+	s.entries = append(s.entries, e)
+
+	return nil
+}
+
+// Attach replays messages buffered by deferred logger to logger `target` preserving their timestamps and
+// forwards all next messages to it. Messages below target level are skipped. Attach does nothing if logger
+// is not created by Deferred.
+func (l *Logger) Attach(target *Logger) {
+	s := l.deferred
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.target = target
+
+	if s.dropped > 0 {
+		target.Warnf("%d early log messages dropped", s.dropped)
+	}
+
+	for _, e := range s.entries {
+		target.forward(e)
+	}
+
+	s.entries = nil
+	s.dropped = 0
+}
+
+// forward writes entry created by other logger if it is not below levels of logger and its outputs.
+func (l *Logger) forward(e *Entry) error {
+	if !l.enabled(e.Level) {
+		return nil
+	}
+
+	_, err := l.emit(e)
+
+	return err
+}
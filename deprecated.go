@@ -0,0 +1,21 @@
+package simplelog
+
+import "sync"
+
+// deprecated contains keys of deprecation warnings already written by process
+var deprecated sync.Map
+
+// Deprecated writes Warn message `<what> is deprecated, <hint>`, e.g. `flag --foo is deprecated, use --bar`.
+// Message is written at most once per unique `what` per process run. Hint is omitted if empty.
+func (l *Logger) Deprecated(what, hint string) (n int, err error) {
+	if _, loaded := deprecated.LoadOrStore(what, struct{}{}); loaded {
+		return 0, nil
+	}
+
+	s := what + " is deprecated"
+	if hint != "" {
+		s += ", " + hint
+	}
+
+	return l.p(LogLevelWarn, s)
+}
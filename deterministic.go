@@ -0,0 +1,33 @@
+package simplelog
+
+import "time"
+
+// deterministicTime is a time of all messages in deterministic mode
+var deterministicTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// DeterministicMode makes output byte-stable across machines and terminals for golden-file comparisons in CI:
+// all messages have fixed time 2000-01-01 00:00:00 UTC and durations of bars, traced calls and uptime are
+// zero, output is rendered as for non-terminal writer even if main writer is terminal, colors, hyperlinks,
+// bell, progress messages, caller info, goroutine IDs and process metadata including details of Startup
+// message are disabled.
+func (l *Logger) DeterministicMode() {
+	l.mu.Lock()
+	out := *l.out.Load()
+	out.isTerminal, out.noTerminal = false, true
+	l.out.Store(&out)
+	l.mu.Unlock()
+
+	l.Clock = func() time.Time { return deterministicTime }
+	l.NoColor = true
+	l.NoHyperlinks = true
+	l.Bell = false
+	l.NoProgress = true
+	l.ReportCaller = false
+	l.ProcessInfo = false
+	l.GoroutineID = false
+}
+
+// deterministic reports whether DeterministicMode is enabled.
+func (l *Logger) deterministic() bool {
+	return l.out.Load().noTerminal
+}
@@ -0,0 +1,52 @@
+package simplelog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDeterministicMode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(buf)
+	l.DeterministicMode()
+	l.SetLevel(LogLevelTrace)
+
+	b := l.NewBar("copy", 10)
+	task := l.ForTask("build")
+	call := l.TraceCall("load", "a.txt")
+	time.Sleep(10 * time.Millisecond)
+	l.TraceExit(call, 3)
+	b.Finish()
+
+	if d := task.Summary().Duration; d != 0 {
+		t.Errorf("task duration is %s, want 0s", d)
+	}
+
+	want := "2000-01-01 00:00:00 |TRC| enter load(a.txt)\n" +
+		"2000-01-01 00:00:00 |TRC| exit load = 3 duration=0s\n" +
+		"2000-01-01 00:00:00 |INF| copy finished in 0s\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	l.SwapOutput(new(bytes.Buffer))
+	if !l.out.Load().noTerminal {
+		t.Error("terminal rendering is enabled after output is swapped")
+	}
+}
+
+func TestDeterministicLifecycle(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(buf)
+	l.DeterministicMode()
+
+	l.Startup("app", "1.0", Fields{"env": "ci"})
+	l.ShutdownReason("done")
+
+	want := "2000-01-01 00:00:00 |INF| service started app=app version=1.0 env=ci\n" +
+		"2000-01-01 00:00:00 |INF| service stopped reason=done uptime=0s info=1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
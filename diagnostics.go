@@ -0,0 +1,138 @@
+package simplelog
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WriteDiagnostics writes zip archive with diagnostics for bug reports to `w`:
+//   - `entries.log`: messages kept by ring buffer output (see KeepLast)
+//   - `summary.txt`: number of messages of each log level and first and last errors
+//   - `runtime.txt`: OS, Go version, number of goroutines and process metadata
+//   - `config.txt`: logger settings
+func (l *Logger) WriteDiagnostics(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name  string
+		write func(w io.Writer) error
+	}{
+		{"entries.log", l.writeDiagnosticEntries},
+		{"summary.txt", l.writeDiagnosticSummary},
+		{"runtime.txt", l.writeDiagnosticRuntime},
+		{"config.txt", l.writeDiagnosticConfig}}
+
+	now := l.now()
+	for _, file := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return fmt.Errorf("write diagnostics: %w", err)
+		}
+
+		if err := file.write(fw); err != nil {
+			return fmt.Errorf("write diagnostics %s: %w", file.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("write diagnostics: %w", err)
+	}
+
+	return nil
+}
+
+// writeDiagnosticEntries writes messages kept by ring buffer.
+func (l *Logger) writeDiagnosticEntries(w io.Writer) error {
+	r := l.ringBuffer()
+	if r == nil {
+		_, err := io.WriteString(w, "logger has no ring buffer output\n")
+		return err
+	}
+
+	return writeEntries(w, r.Entries(0))
+}
+
+// writeDiagnosticSummary writes statistics of messages.
+func (l *Logger) writeDiagnosticSummary(w io.Writer) error {
+	summary := l.Summary()
+
+	l.mu.Lock()
+	dropped := l.summary.dropped
+	l.mu.Unlock()
+
+	pairs := [][2]any{}
+	for level := LogLevelTrace; level <= LogLevelFatal; level++ {
+		pairs = append(pairs, [2]any{level, summary.Counts[level]})
+	}
+	pairs = append(pairs, [2]any{"bytes", summary.Bytes}, [2]any{"dropped", dropped})
+	if summary.FirstError != nil {
+		pairs = append(pairs, [2]any{"first error", summary.FirstError.Message})
+	}
+	if summary.LastError != nil {
+		pairs = append(pairs, [2]any{"last error", summary.LastError.Message})
+	}
+
+	return writeDiagnosticPairs(w, pairs)
+}
+
+// writeDiagnosticRuntime writes runtime information.
+func (l *Logger) writeDiagnosticRuntime(w io.Writer) error {
+	pairs := [][2]any{
+		{"time", l.now().Format(time.RFC3339)},
+		{"os", runtime.GOOS},
+		{"arch", runtime.GOARCH},
+		{"go", runtime.Version()},
+		{"cpus", runtime.NumCPU()},
+		{"goroutines", runtime.NumGoroutine()}}
+
+	for _, f := range processInfo() {
+		pairs = append(pairs, [2]any{f.Key, f.Value})
+	}
+	if l.AppVersion != "" {
+		pairs = append(pairs, [2]any{"version", l.AppVersion})
+	}
+
+	return writeDiagnosticPairs(w, pairs)
+}
+
+// writeDiagnosticConfig writes logger settings.
+func (l *Logger) writeDiagnosticConfig(w io.Writer) error {
+	modules := make([]string, 0, len(l.Modules))
+	for module, level := range l.Modules {
+		modules = append(modules, fmt.Sprintf("%s=%s", module, level))
+	}
+	slices.Sort(modules)
+
+	pairs := [][2]any{
+		{"level", l.Level()},
+		{"format", l.Format},
+		{"terminal", l.terminal()},
+		{"time format", l.TimeFormat},
+		{"no color", l.NoColor},
+		{"no progress", l.NoProgress},
+		{"progress level", l.ProgressLevel},
+		{"caller tag", l.CallerTag},
+		{"report caller", l.ReportCaller},
+		{"modules", strings.Join(modules, " ")},
+		{"outputs", len(l.Outputs)},
+		{"sync level", l.SyncLevel},
+		{"sync interval", l.SyncInterval}}
+
+	return writeDiagnosticPairs(w, pairs)
+}
+
+// writeDiagnosticPairs writes `key: value` lines.
+func writeDiagnosticPairs(w io.Writer, pairs [][2]any) error {
+	for _, pair := range pairs {
+		if _, err := fmt.Fprintf(w, "%v: %v\n", pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package simplelog
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteDiagnosticsClock(t *testing.T) {
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLogger(io.Discard)
+	l.Clock = func() time.Time { return now }
+
+	buf := new(bytes.Buffer)
+	if err := l.WriteDiagnostics(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range zr.File {
+		if f.Modified.Year() != 2000 {
+			t.Errorf("%s is modified at %s, want logger time", f.Name, f.Modified)
+		}
+
+		if f.Name != "runtime.txt" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+
+		if want := "time: " + now.Format(time.RFC3339); !strings.Contains(string(b), want) {
+			t.Errorf("runtime.txt does not contain %q:\n%s", want, b)
+		}
+	}
+}
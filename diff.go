@@ -0,0 +1,210 @@
+package simplelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// diffContext is a number of unchanged lines shown around changes
+	diffContext = 3
+
+	// maxDiffCells is a maximum size of LCS table of changed lines
+	maxDiffCells = 1 << 20
+)
+
+var (
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00"))
+	diffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#00ffff"))
+)
+
+// Diff writes message `label` of log level `logLevel` with line diff of `old` and `new` in unified format.
+// Strings are compared as is, other values are marshalled to indented JSON. Terminal shows colored diff
+// below label; other outputs record it as `diff` field.
+func (l *Logger) Diff(logLevel LogLevel, label string, old, new any) (n int, err error) {
+	diff := unifiedDiff(diffLines(old), diffLines(new))
+
+	if !l.terminal() {
+		return l.log(logLevel, label, "", []Field{{"diff", diff}})
+	}
+
+	if diff == "" {
+		return l.log(logLevel, label, sanitize(label, l.Sanitize)+": no changes", []Field{{"diff", diff}})
+	}
+
+	label = sanitize(label, l.Sanitize)
+	lines := strings.Split(strings.TrimSuffix(sanitize(diff, l.Sanitize), "\n"), "\n")
+	if l.colored() {
+		for i, line := range lines {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				lines[i] = diffHunkStyle.Render(line)
+			case strings.HasPrefix(line, "-"):
+				lines[i] = diffRemovedStyle.Render(line)
+			case strings.HasPrefix(line, "+"):
+				lines[i] = diffAddedStyle.Render(line)
+			}
+		}
+	}
+
+	return l.log(logLevel, label, label+"\n"+strings.Join(lines, "\n"), []Field{{"diff", diff}})
+}
+
+// diffLines returns lines of value compared by Diff.
+func diffLines(v any) []string {
+	s, ok := v.(string)
+	if !ok {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			s = fmt.Sprint(v)
+		} else {
+			s = string(b)
+		}
+	}
+
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffEdit is an edit of line diff: ' ' for equal line, '-' for removed and '+' for added one. Positions
+// are indexes of line in old and new lines.
+type diffEdit struct {
+	op         byte
+	line       string
+	aPos, bPos int
+}
+
+// unifiedDiff returns diff of lines `a` and `b` in unified format without file headers or empty string if
+// lines are equal.
+func unifiedDiff(a, b []string) string {
+	// common prefix and suffix are not compared by LCS
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]diffEdit, 0, len(a)+len(b))
+	for i := range prefix {
+		edits = append(edits, diffEdit{' ', a[i], i, i})
+	}
+	edits = append(edits, diffEdits(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix)...)
+	for k := range suffix {
+		i, j := len(a)-suffix+k, len(b)-suffix+k
+		edits = append(edits, diffEdit{' ', a[i], i, j})
+	}
+
+	sb := new(strings.Builder)
+	for start := 0; start < len(edits); {
+		// find next change
+		for start < len(edits) && edits[start].op == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+
+		// extend hunk while changes are closer than two contexts
+		end := start
+		for k := start; k < len(edits); k++ {
+			if edits[k].op != ' ' {
+				end = k + 1
+			} else if k-end >= 2*diffContext {
+				break
+			}
+		}
+
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext, len(edits))
+
+		aLen, bLen := 0, 0
+		for _, e := range edits[from:to] {
+			if e.op != '+' {
+				aLen++
+			}
+			if e.op != '-' {
+				bLen++
+			}
+		}
+		// empty ranges start at line before them
+		aStart, bStart := edits[from].aPos+1, edits[from].bPos+1
+		if aLen == 0 {
+			aStart--
+		}
+		if bLen == 0 {
+			bStart--
+		}
+		fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen)
+
+		for _, e := range edits[from:to] {
+			sb.WriteByte(e.op)
+			sb.WriteString(e.line)
+			sb.WriteByte('\n')
+		}
+
+		start = to
+	}
+
+	return sb.String()
+}
+
+// diffEdits returns edit script of lines `a` and `b` which start at line `offset` of compared values. If LCS
+// table would exceed maxDiffCells, all lines of `a` are removed and all lines of `b` are added, so memory
+// stays bounded on large inputs.
+func diffEdits(a, b []string, offset int) []diffEdit {
+	edits := make([]diffEdit, 0, len(a)+len(b))
+
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for i, line := range a {
+			edits = append(edits, diffEdit{'-', line, offset + i, offset})
+		}
+		for j, line := range b {
+			edits = append(edits, diffEdit{'+', line, offset + len(a), offset + j})
+		}
+
+		return edits
+	}
+
+	// lcs[i][j] is a length of longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, diffEdit{' ', a[i], offset + i, offset + j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, diffEdit{'-', a[i], offset + i, offset + j})
+			i++
+		default:
+			edits = append(edits, diffEdit{'+', b[j], offset + i, offset + j})
+			j++
+		}
+	}
+
+	return edits
+}
@@ -0,0 +1,42 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want string
+	}{
+		{"equal", []string{"a", "b"}, []string{"a", "b"}, ""},
+		{"changed", []string{"a", "b", "c"}, []string{"a", "x", "c"}, "@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n"},
+		{"added", nil, []string{"a"}, "@@ -0,0 +1,1 @@\n+a\n"},
+		{"removed", []string{"a", "b"}, []string{"b"}, "@@ -1,2 +1,1 @@\n-a\n b\n"},
+		{"moved", []string{"a", "b", "c"}, []string{"b", "c", "a"}, "@@ -1,3 +1,3 @@\n-a\n b\n c\n+a\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := unifiedDiff(test.a, test.b); got != test.want {
+				t.Errorf("got diff:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiffLarge(t *testing.T) {
+	a, b := make([]string, 5000), make([]string, 5000)
+	for i := range a {
+		a[i], b[i] = fmt.Sprint("a", i), fmt.Sprint("b", i)
+	}
+	a, b = append([]string{"head"}, a...), append([]string{"head"}, b...)
+
+	diff := unifiedDiff(a, b)
+	if !strings.HasPrefix(diff, "@@ -1,5001 +1,5001 @@\n head\n-a0\n") || strings.Count(diff, "\n") != 10002 {
+		t.Errorf("got diff of %d lines starting with %q", strings.Count(diff, "\n"), diff[:min(len(diff), 40)])
+	}
+}
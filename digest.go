@@ -0,0 +1,165 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DigestSink is a sink which aggregates entries over time window and writes periodic digest of them to
+// secondary sink, e.g. `last 1m0s: 1203 info, 4 warn, 1 error; top error: connect to db (x1)`, for
+// low-noise operational channels. Digest has level of most severe aggregated entry but not lower than
+// Info and records counts of levels as fields. Windows without entries are not reported.
+type DigestSink struct {
+	// Clock returns time of digests. Current time is used if nil. It must not be changed after first digest
+	// is written.
+	Clock func() time.Time
+
+	dest   Sink
+	window time.Duration
+
+	// counts of entries by level and of error entries by message template in current window
+	counts map[LogLevel]int
+	errors map[string]*digestError
+
+	errorHandler func(error)
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	mu        sync.Mutex
+}
+
+// digestError represents error entries of the same message template
+type digestError struct {
+	// message of first entry
+	message string
+	count   int
+}
+
+// NewDigestSink returns new sink which writes digest of entries to `dest` every `window`.
+func NewDigestSink(dest Sink, window time.Duration) *DigestSink {
+	s := &DigestSink{
+		dest:   dest,
+		window: window,
+		counts: make(map[LogLevel]int),
+		errors: make(map[string]*digestError),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{})}
+
+	go s.run()
+
+	return s
+}
+
+// SetErrorHandler sets handler of errors of writing digests.
+func (s *DigestSink) SetErrorHandler(h func(error)) {
+	s.mu.Lock()
+	s.errorHandler = h
+	s.mu.Unlock()
+}
+
+// WriteEntry implements Sink.
+func (s *DigestSink) WriteEntry(e *Entry) error {
+	if e.Level == LogLevelProgress {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[e.Level]++
+	if e.Level >= LogLevelError {
+		template := normalizeTemplate(e.Message)
+		if s.errors[template] == nil {
+			s.errors[template] = &digestError{message: e.Message}
+		}
+		s.errors[template].count++
+	}
+
+	return nil
+}
+
+// Close writes digest of current window and stops background goroutine.
+func (s *DigestSink) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+
+	return nil
+}
+
+// run writes digests on each window until sink is closed.
+func (s *DigestSink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.stop:
+			s.flush()
+			return
+		}
+	}
+}
+
+// flush writes digest of current window and starts new one.
+func (s *DigestSink) flush() {
+	s.mu.Lock()
+	counts, errors := s.counts, s.errors
+	s.counts, s.errors = make(map[LogLevel]int), make(map[string]*digestError)
+	h := s.errorHandler
+	s.mu.Unlock()
+
+	if len(counts) == 0 {
+		return
+	}
+
+	if err := s.dest.WriteEntry(s.digest(counts, errors)); err != nil && h != nil {
+		h(fmt.Errorf("write log digest: %w", err))
+	}
+}
+
+// digest returns digest entry of window with entry counts `counts` and errors `errors` grouped by message
+// template.
+func (s *DigestSink) digest(counts map[LogLevel]int, errors map[string]*digestError) *Entry {
+	e := &Entry{Time: s.now(), Level: LogLevelInfo}
+
+	var parts []string
+	for level := LogLevelTrace; level <= LogLevelFatal; level++ {
+		if count := counts[level]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, level))
+			e.Fields = append(e.Fields, Field{level.String(), count})
+			e.Level = max(e.Level, level)
+		}
+	}
+
+	e.Message = fmt.Sprintf("last %s: %s", s.window, strings.Join(parts, ", "))
+
+	var top *digestError
+	for _, err := range errors {
+		if top == nil || err.count > top.count || (err.count == top.count && err.message < top.message) {
+			top = err
+		}
+	}
+	if top != nil {
+		e.Message += fmt.Sprintf("; top error: %s (x%d)", top.message, top.count)
+	}
+
+	return e
+}
+
+// now returns current time of sink clock.
+func (s *DigestSink) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock()
+	}
+
+	return time.Now()
+}
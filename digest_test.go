@@ -0,0 +1,43 @@
+package simplelog
+
+import (
+	"testing"
+	"time"
+)
+
+// entrySink is a sink which collects written entries
+type entrySink struct {
+	entries []*Entry
+}
+
+func (s *entrySink) WriteEntry(e *Entry) error {
+	s.entries = append(s.entries, e)
+
+	return nil
+}
+
+func TestDigestClock(t *testing.T) {
+	dest := new(entrySink)
+	s := NewDigestSink(dest, time.Hour)
+	clock := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.Clock = func() time.Time { return clock }
+
+	s.WriteEntry(&Entry{Level: LogLevelInfo, Message: "started"})
+	s.WriteEntry(&Entry{Level: LogLevelError, Message: "connect to db"})
+	s.Close()
+
+	if len(dest.entries) != 1 {
+		t.Fatalf("got %d digests, want 1", len(dest.entries))
+	}
+
+	e := dest.entries[0]
+	if !e.Time.Equal(clock) {
+		t.Errorf("digest time is %s, want %s", e.Time, clock)
+	}
+	if want := "last 1h0m0s: 1 info, 1 error; top error: connect to db (x1)"; e.Message != want {
+		t.Errorf("got message %q, want %q", e.Message, want)
+	}
+	if e.Level != LogLevelError {
+		t.Errorf("got level %s, want %s", e.Level, LogLevelError)
+	}
+}
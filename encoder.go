@@ -0,0 +1,308 @@
+package simplelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Encoder encodes entries to bytes written to output
+type Encoder interface {
+	// Encode returns representation of entry `e` including trailing new line if needed.
+	Encode(e *Entry) ([]byte, error)
+}
+
+// TextEncoder encodes entries to plain text lines with `|INF|`-like level prefixes
+type TextEncoder struct {
+	// Timestamp format. Timestamp is not written if empty.
+	TimeFormat string
+
+	// SortFields enables sorting of fields by key instead of insertion order
+	SortFields bool
+
+	// LeadingKeys are keys of fields written before other fields in given order
+	LeadingKeys []string
+
+	// Rename maps field keys to output keys
+	Rename map[string]string
+
+	// MaxBytes is a maximum number of bytes of []byte field values written as hex. Zero value means no limit.
+	MaxBytes int
+
+	// PrefixFormat is a format of level prefix
+	PrefixFormat PrefixFormat
+
+	// Layout is a template of line with `{time}`, `{level}`, `{name}`, `{message}` and `{fields}`
+	// placeholders, e.g. `{level} {time} {message} {fields}`. Components missing in template are not
+	// written. Default layout is `{time} {level} {name} {message} {fields}`.
+	Layout string
+
+	// Sanitize is a set of rules applied to message text and string field values
+	Sanitize Sanitize
+}
+
+// NewTextEncoder returns new text encoder with default timestamp format and sanitization rules.
+func NewTextEncoder() *TextEncoder {
+	return &TextEncoder{TimeFormat: defaultFileTimestampFormat, Sanitize: defaultSanitize}
+}
+
+// Encode implements Encoder.
+func (enc *TextEncoder) Encode(e *Entry) ([]byte, error) {
+	m := &msg{
+		Prefix: formatPrefix(enc.PrefixFormat, e.Level),
+		Text:   quoteText(sanitize(e.Message, enc.Sanitize)),
+		Layout: enc.Layout,
+	}
+
+	fields := sanitizeFields(entryFields(e, true), enc.Sanitize)
+	if enc.MaxBytes > 0 {
+		fields = bytesFields(fields, BytesHex, enc.MaxBytes)
+	}
+	m.Fields = formatFields(renameFields(orderFields(fields, enc.SortFields, enc.LeadingKeys), enc.Rename))
+
+	if enc.TimeFormat != "" {
+		m.TimeStamp = e.Time.Format(enc.TimeFormat)
+	}
+
+	if e.Source != "" {
+		m.Tag = e.Source + " |"
+	}
+
+	return []byte(m.String() + "\n"), nil
+}
+
+// JSONEncoder encodes entries to single-line JSON objects
+type JSONEncoder struct {
+	// Timestamp format. Timestamp is not written if empty.
+	TimeFormat string
+
+	// UTC enables conversion of timestamps to UTC
+	UTC bool
+
+	// LevelNames overrides names of log levels, e.g. `INFO` instead of `info`
+	LevelNames map[LogLevel]string
+
+	// Rename maps standard keys (`time`, `level`, `source`, `msg`, `caller`) and field keys to output keys
+	Rename map[string]string
+
+	// SortFields enables sorting of fields by key instead of insertion order. Standard keys are written
+	// before fields except caller key which is sorted with fields.
+	SortFields bool
+
+	// LeadingKeys are standard or field keys written first in given order. Other keys follow in default
+	// order: `time`, `level`, `source`, `msg`, fields, `caller`.
+	LeadingKeys []string
+
+	// Bytes is an encoding of []byte field values. Default is hex.
+	Bytes BytesEncoding
+
+	// MaxBytes is a maximum number of encoded bytes of []byte field values. Zero value means no limit.
+	MaxBytes int
+
+	// Sanitize is a set of rules applied to message text and string field values. Control characters are
+	// escaped by JSON anyway, so rules are mostly useful to strip ANSI escape sequences.
+	Sanitize Sanitize
+}
+
+// NewJSONEncoder returns new JSON encoder with RFC 3339 timestamps which strips ANSI escape sequences.
+func NewJSONEncoder() *JSONEncoder {
+	return &JSONEncoder{TimeFormat: time.RFC3339Nano, Sanitize: defaultJSONSanitize}
+}
+
+// Encode implements Encoder.
+func (enc *JSONEncoder) Encode(e *Entry) ([]byte, error) {
+	sb := new(strings.Builder)
+	sb.WriteRune('{')
+
+	var pairs []Field
+
+	if enc.TimeFormat != "" {
+		t := e.Time
+		if enc.UTC {
+			t = t.UTC()
+		}
+		pairs = append(pairs, Field{"time", t.Format(enc.TimeFormat)})
+	}
+
+	level, exists := enc.LevelNames[e.Level]
+	if !exists {
+		level = e.Level.String()
+	}
+	pairs = append(pairs, Field{"level", level})
+	if e.Source != "" {
+		pairs = append(pairs, Field{"source", e.Source})
+	}
+	pairs = append(pairs, Field{"msg", sanitize(e.Message, enc.Sanitize)})
+
+	fields := sanitizeFields(entryFields(e, true), enc.Sanitize)
+	if enc.Bytes != BytesHex || enc.MaxBytes > 0 {
+		fields = bytesFields(fields, enc.Bytes, enc.MaxBytes)
+	}
+	if enc.SortFields {
+		fields = orderFields(fields, true, nil)
+	}
+	pairs = append(pairs, fields...)
+
+	for i, f := range renameFields(orderFields(pairs, false, enc.LeadingKeys), enc.Rename) {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+
+		value := fieldValue(f.Value)
+
+		k, _ := json.Marshal(f.Key)
+		v, err := json.Marshal(value)
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(value))
+		}
+
+		sb.Write(k)
+		sb.WriteRune(':')
+		sb.Write(v)
+	}
+
+	sb.WriteString("}\n")
+
+	return []byte(sb.String()), nil
+}
+
+// SyslogEncoder encodes entries to RFC 5424 syslog messages
+type SyslogEncoder struct {
+	// Syslog facility, default is 1 (user-level messages)
+	Facility int
+
+	// Host name, default is host name of machine
+	Hostname string
+
+	// Application name, default is executable name
+	AppName string
+}
+
+// NewSyslogEncoder returns new syslog encoder with application name `appName`.
+func NewSyslogEncoder(appName string) *SyslogEncoder {
+	host, _ := os.Hostname()
+
+	return &SyslogEncoder{Facility: 1, Hostname: host, AppName: appName}
+}
+
+// Encode implements Encoder.
+func (enc *SyslogEncoder) Encode(e *Entry) ([]byte, error) {
+	host, app := syslogValue(enc.Hostname), syslogValue(enc.AppName)
+	if enc.AppName == "" {
+		for _, f := range processInfo() {
+			if f.Key == "exe" {
+				app = syslogValue(fmt.Sprint(f.Value))
+			}
+		}
+	}
+
+	text := e.Message
+	if e.Source != "" {
+		text = e.Source + ": " + text
+	}
+	if fields := formatFields(entryFields(e, false)); fields != "" {
+		text += " " + fields
+	}
+
+	return fmt.Appendf(nil, "<%d>1 %s %s %s %d - - %s\n",
+		enc.Facility*8+syslogSeverity(e.Level), e.Time.Format(time.RFC3339Nano), host, app, os.Getpid(), text), nil
+}
+
+// syslogSeverity returns syslog severity of log level.
+func syslogSeverity(logLevel LogLevel) int {
+	switch logLevel {
+	case LogLevelTrace, LogLevelDebug:
+		return 7
+	case LogLevelInfo, LogLevelProgress:
+		return 6
+	case LogLevelWarn:
+		return 4
+	case LogLevelError:
+		return 3
+	case LogLevelFatal:
+		return 2
+	}
+
+	return 5
+}
+
+// syslogValue returns syslog header value or NILVALUE if `s` is empty.
+func syslogValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return strings.ReplaceAll(s, " ", "_")
+}
+
+// entryFields returns fields of entry including caller. Process metadata fields are included only if
+// `withMetadata` is true.
+func entryFields(e *Entry, withMetadata bool) []Field {
+	fields := e.Fields
+	if !withMetadata {
+		fields = fields[e.metadata:]
+	}
+
+	if e.HasCaller() {
+		fields = append(fields[:len(fields):len(fields)], Field{"caller", e.CallerString()})
+	}
+
+	return fields
+}
+
+// orderFields returns fields sorted by key if `sorted` is true with first fields of keys `leading` moved to
+// the beginning in given order. Fields `fields` are not modified.
+func orderFields(fields []Field, sorted bool, leading []string) []Field {
+	if !sorted && len(leading) == 0 {
+		return fields
+	}
+
+	fields = slices.Clone(fields)
+	if sorted {
+		slices.SortStableFunc(fields, func(a, b Field) int { return strings.Compare(a.Key, b.Key) })
+	}
+
+	n := 0
+	for _, key := range leading {
+		for i := n; i < len(fields); i++ {
+			if fields[i].Key == key {
+				f := fields[i]
+				copy(fields[n+1:i+1], fields[n:i])
+				fields[n] = f
+				n++
+				break
+			}
+		}
+	}
+
+	return fields
+}
+
+// renameFields returns fields with keys replaced according to `rename`. Fields `fields` are not modified.
+func renameFields(fields []Field, rename map[string]string) []Field {
+	if len(rename) == 0 {
+		return fields
+	}
+
+	renamed := make([]Field, len(fields))
+	for i, f := range fields {
+		if key, exists := rename[f.Key]; exists {
+			f.Key = key
+		}
+		renamed[i] = f
+	}
+
+	return renamed
+}
+
+// terminalEntryFields returns fields of entry written to terminal: without process metadata and fields
+// replaced by display text.
+func terminalEntryFields(e *Entry) []Field {
+	t := *e
+	t.Fields = e.Fields[:len(e.Fields)-e.hidden]
+
+	return entryFields(&t, false)
+}
@@ -0,0 +1,90 @@
+package simplelog
+
+import (
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry represents single log message
+type Entry struct {
+	// Time of message
+	Time time.Time
+
+	// Log level of message
+	Level LogLevel
+
+	// Name of message source, e.g. Mux source or caller tag
+	Source string
+
+	// Message text
+	Message string
+
+	// Fields attached to message
+	Fields []Field
+
+	// Caller which emitted message. Caller is filled only if caller reporting is enabled.
+	Caller runtime.Frame
+
+	// number of leading process metadata fields which are not written to terminal
+	metadata int
+
+	// text shown on terminal instead of message, e.g. translated message, and number of trailing fields
+	// which are not written to terminal with it
+	display string
+	hidden  int
+}
+
+// HasCaller reports whether entry contains caller info.
+func (e *Entry) HasCaller() bool {
+	return e.Caller.PC != 0 || e.Caller.File != ""
+}
+
+// CallerString returns short `dir/file.go:line` representation of entry caller or empty string if entry
+// does not contain caller info.
+func (e *Entry) CallerString() string {
+	if !e.HasCaller() {
+		return ""
+	}
+
+	dir, file := path.Split(e.Caller.File)
+	dir = path.Base(strings.TrimSuffix(dir, "/"))
+
+	return dir + "/" + file + ":" + strconv.Itoa(e.Caller.Line)
+}
+
+// newEntry returns new entry of message `s` with fields of logger.
+func (l *Logger) newEntry(t time.Time, logLevel LogLevel, s string) *Entry {
+	entry := &Entry{
+		Time:    t,
+		Level:   logLevel,
+		Source:  l.name,
+		Message: s,
+	}
+
+	if entry.Source == "" {
+		entry.Source = callerTag(l.CallerTag)
+	}
+
+	if l.StripMessages {
+		entry.Message = strings.TrimSpace(entry.Message)
+	}
+
+	if l.ReportCaller {
+		entry.Caller = caller()
+	}
+
+	metadata := l.metadataFields()
+	entry.metadata = len(metadata)
+
+	entry.Fields = make([]Field, 0, len(metadata)+len(l.fields)+1)
+	entry.Fields = append(entry.Fields, metadata...)
+	entry.Fields = append(entry.Fields, l.verboseFields(logLevel, l.fields)...)
+	if l.GoroutineID {
+		entry.Fields = append(entry.Fields, Field{"goroutine", goroutineID()})
+	}
+
+	return entry
+}
@@ -0,0 +1,28 @@
+package simplelog
+
+import "fmt"
+
+// Errore writes Error message with text of `err` and returns `err`, so functions can log and return error in
+// single statement. Nothing is written if `err` is nil.
+func (l *Logger) Errore(err error) error {
+	if err != nil {
+		l.logError(LogLevelError, err.Error(), err)
+	}
+
+	return err
+}
+
+// Warne writes Warn message with text of `err` and returns `err`. Nothing is written if `err` is nil.
+func (l *Logger) Warne(err error) error {
+	if err != nil {
+		l.logError(LogLevelWarn, err.Error(), err)
+	}
+
+	return err
+}
+
+// Errorfe returns error formatted by fmt.Errorf, so it can wrap errors with `%w`, and writes it as Error
+// message.
+func (l *Logger) Errorfe(format string, a ...any) error {
+	return l.Errore(fmt.Errorf(format, a...))
+}
@@ -0,0 +1,112 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorCause represents error of chain of wrapped errors
+type ErrorCause struct {
+	// Type name of error, e.g. `*fs.PathError`
+	Type string `json:"type"`
+
+	// Error text
+	Error string `json:"error"`
+
+	// Depth of error in chain, 0 for outermost error
+	Depth int `json:"depth"`
+
+	// text added by error to text of wrapped errors, shown in tree
+	own string
+}
+
+// String returns cause in `type: text` form.
+func (c ErrorCause) String() string {
+	return c.Type + ": " + c.Error
+}
+
+// errorCauses returns chain of errors wrapped by `err` including `err` itself. Errors joined by errors.Join
+// or wrapped by several `%w` verbs are all included one level deeper than wrapping error.
+func errorCauses(err error) []ErrorCause {
+	var causes []ErrorCause
+
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		if err == nil {
+			return
+		}
+
+		cause := ErrorCause{Type: fmt.Sprintf("%T", err), Error: err.Error(), Depth: depth}
+
+		var wrapped []error
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			if w := u.Unwrap(); w != nil {
+				// `context: wrapped error` is shown as `context`
+				cause.own = strings.TrimSuffix(strings.TrimSuffix(cause.Error, w.Error()), ": ")
+				wrapped = []error{w}
+			} else {
+				cause.own = cause.Error
+			}
+		case interface{ Unwrap() []error }:
+			wrapped = u.Unwrap()
+		default:
+			cause.own = cause.Error
+		}
+
+		causes = append(causes, cause)
+		for _, err := range wrapped {
+			walk(err, depth+1)
+		}
+	}
+	walk(err, 0)
+
+	return causes
+}
+
+// logError writes message `s` about error `err` with its chain like Print does for error arguments.
+func (l *Logger) logError(logLevel LogLevel, s string, err error) (n int, _ error) {
+	args := []any{err}
+	template := func() string { return normalizeTemplate(s) }
+	causes, display := l.errorTree(logLevel, s, args)
+
+	return l.log(logLevel, s, display, append(l.fingerprintFields(logLevel, template, args), causes...))
+}
+
+// errorTree returns `causes` field with chain of first error among arguments `args` which wraps other errors
+// and terminal text of message `s` with chain shown as indented tree below it. Nothing is returned if no
+// argument wraps errors or message is not written.
+func (l *Logger) errorTree(logLevel LogLevel, s string, args []any) (fields []Field, display string) {
+	var causes []ErrorCause
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			if causes = errorCauses(err); len(causes) > 1 {
+				break
+			}
+		}
+	}
+	if len(causes) <= 1 || !l.enabled(logLevel) {
+		return nil, ""
+	}
+
+	fields = []Field{{"causes", causes}}
+
+	if !l.terminal() {
+		return fields, ""
+	}
+
+	lines := make([]string, len(causes))
+	for i, cause := range causes {
+		line := strings.Repeat("  ", cause.Depth) + "└ " + sanitize(cause.Type, l.Sanitize)
+		if own := strings.ReplaceAll(sanitize(cause.own, l.Sanitize), "\n", "; "); own != "" {
+			line += ": " + own
+		}
+		if l.colored() {
+			line = l.FieldStyle.Render(line)
+		}
+
+		lines[i] = line
+	}
+
+	return fields, sanitize(s, l.Sanitize) + "\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,68 @@
+package simplelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+func TestErrorCausesField(t *testing.T) {
+	base := errors.New("file does not exist")
+	// format is not constant, so vet does not report `%w` verb in printf-like method
+	format := "start: %w"
+	wrapped := fmt.Errorf("load config: %w", &fs.PathError{Op: "open", Path: "app.toml", Err: base})
+
+	tests := []struct {
+		name    string
+		log     func(l *Logger)
+		message string
+		causes  int
+	}{
+		{"Error", func(l *Logger) { l.Error(wrapped) }, wrapped.Error(), 3},
+		{"Errorln", func(l *Logger) { l.Errorln("failed:", wrapped) }, "failed: " + wrapped.Error(), 3},
+		{"Errorf", func(l *Logger) { l.Errorf(format, base) }, "start: file does not exist", 2},
+		{"Errorf wrapped", func(l *Logger) { l.Errorf(format, wrapped) }, "start: " + wrapped.Error(), 4},
+		{"Errorf join", func(l *Logger) { l.Errorf("start: %v", errors.Join(base, base)) }, "start: file does not exist\nfile does not exist", 3},
+		{"Errore", func(l *Logger) { l.Errore(wrapped) }, wrapped.Error(), 3},
+		{"plain", func(l *Logger) { l.Error(base) }, base.Error(), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			l := NewLogger(buf)
+			l.Format = FormatJSON
+
+			test.log(l)
+
+			var entry struct {
+				Msg    string
+				Causes []ErrorCause
+			}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("%v: %s", err, buf.String())
+			}
+			if entry.Msg != test.message {
+				t.Errorf("message is %q, want %q", entry.Msg, test.message)
+			}
+			if len(entry.Causes) != test.causes {
+				t.Errorf("got %d causes, want %d: %v", len(entry.Causes), test.causes, entry.Causes)
+			}
+		})
+	}
+}
+
+func TestErrorTreeTerminal(t *testing.T) {
+	l := NewLogger(new(bytes.Buffer))
+	l.out.Store(&mainWriter{w: l.Output(), isTerminal: true})
+	l.NoColor = true
+
+	_, display := l.errorTree(LogLevelError, "failed", []any{fmt.Errorf("load: %w", errors.New("boom"))})
+
+	if want := "failed\n└ *fmt.wrapError: load\n  └ *errors.errorString: boom"; display != want {
+		t.Errorf("got tree:\n%s\nwant:\n%s", display, want)
+	}
+}
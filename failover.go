@@ -0,0 +1,164 @@
+package simplelog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FailoverSink is a sink which writes entries to first healthy output of ordered chain, e.g. network sink,
+// then local file, then stderr. When write to output fails, entry is written to next one and following
+// entries go there too. Failed outputs are retried with the next entry after RetryInterval; when output
+// recovers, recovery notice is written to it. Sinks based on BatchSink, e.g. CloudWatchSink, accept entries
+// before they are delivered, so their failure is detected when delivery of batch fails after all retries;
+// entries of undelivered batch are then written to next output.
+type FailoverSink struct {
+	// RetryInterval is a minimum time between retries of failed outputs
+	RetryInterval time.Duration
+
+	outputs []*Output
+
+	// index of output entries are currently written to
+	active int
+
+	// time of last failure of output preceding active one and time of failure which started fallback
+	failed, since time.Time
+
+	// number of entries written to fallback outputs since last failure
+	fallback int
+
+	// last recovery notice, it is not written to fallback output if its delivery fails
+	probe *Entry
+
+	errorHandler func(error)
+	mu           sync.Mutex
+}
+
+// NewFailoverSink returns new sink which writes entries to outputs `outputs` in order of preference. Outputs
+// are made like those of Logger.Outputs: with encoder and writer or with sink.
+func NewFailoverSink(outputs ...*Output) *FailoverSink {
+	s := &FailoverSink{
+		RetryInterval: defaultFailoverRetryInterval,
+		outputs:       outputs}
+
+	for i, output := range outputs {
+		if sink, ok := output.Sink.(interface {
+			setUndeliveredHandler(func(batch []*Entry, err error))
+		}); ok && i+1 < len(outputs) {
+			sink.setUndeliveredHandler(func(batch []*Entry, err error) { s.undelivered(i, batch, err) })
+		}
+	}
+
+	return s
+}
+
+// SetErrorHandler sets handler of output failures. It is passed to outputs which accept error handler too.
+func (s *FailoverSink) SetErrorHandler(h func(error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errorHandler = h
+	for _, output := range s.outputs {
+		if output.Sink != nil {
+			setErrorHandler(output.Sink, h)
+		} else {
+			setErrorHandler(output.Writer, h)
+		}
+	}
+}
+
+// WriteEntry implements Sink. It returns error only if all outputs failed.
+func (s *FailoverSink) WriteEntry(e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.outputs) == 0 {
+		return nil
+	}
+
+	start := s.active
+	if start > 0 && time.Since(s.failed) >= s.RetryInterval {
+		start = 0
+	}
+
+	return s.writeFrom(start, e)
+}
+
+// writeFrom writes entry `e` to first healthy output starting from output `start`. Must be called with
+// locked mutex.
+func (s *FailoverSink) writeFrom(start int, e *Entry) error {
+	var err error
+	for i := start; i < len(s.outputs); i++ {
+		// recovery notice written to failed output checks it before entry
+		err = nil
+		if i < s.active {
+			s.probe = s.notice()
+			err = s.outputs[i].write(s.probe)
+		}
+		if err == nil {
+			err = s.outputs[i].write(e)
+		}
+		if err != nil {
+			s.fail(i, err)
+			continue
+		}
+
+		if i < s.active {
+			s.fallback = 0
+		}
+		s.active = i
+		if i > 0 {
+			s.fallback++
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("all %s failed: %w", plural(len(s.outputs), "log output"), err)
+}
+
+// undelivered records failure of output `i` to deliver entries `batch` and writes them to next outputs.
+func (s *FailoverSink) undelivered(i int, batch []*Entry, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// entries go to active output unless it is failed one
+	start := s.active
+	if i <= s.active {
+		s.fail(i, err)
+		start = max(start, i+1)
+	}
+
+	for _, e := range batch {
+		if e == s.probe {
+			continue
+		}
+
+		if err := s.writeFrom(max(start, s.active), e); err != nil && s.errorHandler != nil {
+			s.errorHandler(err)
+		}
+	}
+}
+
+// fail records failure of output `i` with error `err`. Must be called with locked mutex.
+func (s *FailoverSink) fail(i int, err error) {
+	if i <= s.active {
+		s.failed = time.Now()
+	}
+	if i == s.active && s.fallback == 0 {
+		s.since = s.failed
+	}
+
+	if s.errorHandler != nil && i+1 < len(s.outputs) {
+		s.errorHandler(fmt.Errorf("write to log output %d, falling back to output %d: %w", i+1, i+2, err))
+	}
+}
+
+// notice returns recovery notice of failed output. Must be called with locked mutex.
+func (s *FailoverSink) notice() *Entry {
+	return &Entry{
+		Time:    time.Now(),
+		Level:   LogLevelWarn,
+		Message: fmt.Sprintf("log output recovered, %s written to fallback output", plural(s.fallback, "message")),
+		Fields:  []Field{{"since", s.since.Format(time.RFC3339)}}}
+}
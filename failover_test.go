@@ -0,0 +1,97 @@
+package simplelog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a buffer safe for concurrent use
+type syncBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestFailoverBatchSink(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+
+	var delivered []string
+	var mu sync.Mutex
+	primary := NewBatchSink(func(ctx context.Context, batch []*Entry) error {
+		if down.Load() {
+			return errors.New("service unavailable")
+		}
+
+		mu.Lock()
+		for _, e := range batch {
+			delivered = append(delivered, e.Message)
+		}
+		mu.Unlock()
+
+		return nil
+	}, BatchOptions{Size: 1, Interval: time.Millisecond, MaxRetries: -1})
+	defer primary.Close()
+
+	fallback := new(syncBuffer)
+	s := NewFailoverSink(&Output{Sink: primary}, &Output{Encoder: &TextEncoder{}, Writer: fallback})
+	s.RetryInterval = 50 * time.Millisecond
+
+	if err := s.WriteEntry(&Entry{Level: LogLevelInfo, Message: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return strings.Contains(fallback.String(), "first") })
+
+	if err := s.WriteEntry(&Entry{Level: LogLevelInfo, Message: "second"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(fallback.String(), "second") {
+		t.Error("entry is not written to fallback output after delivery failure")
+	}
+
+	down.Store(false)
+	time.Sleep(s.RetryInterval)
+	if err := s.WriteEntry(&Entry{Level: LogLevelInfo, Message: "third"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(delivered) == 2
+	})
+
+	if !strings.HasPrefix(delivered[0], "log output recovered, 2 messages") || delivered[1] != "third" {
+		t.Errorf("primary output received %q", delivered)
+	}
+}
+
+// waitFor waits until `f` returns true or fails test after timeout.
+func waitFor(t *testing.T, f func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); !f(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package simplelog
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Field represents key-value pair attached to message
+type Field struct {
+	Key   string
+	Value any
+}
+
+// formatFields returns `key=value` representation of fields `fields`.
+func formatFields(fields []Field) string {
+	sb := new(strings.Builder)
+
+	for i, f := range fields {
+		if i > 0 {
+			sb.WriteRune(' ')
+		}
+
+		sb.WriteString(f.Key)
+		sb.WriteRune('=')
+		sb.WriteString(formatValue(f.Value))
+	}
+
+	return sb.String()
+}
+
+// formatValue returns string representation of field value converted by registered formatter and quoted
+// if needed.
+func formatValue(v any) string {
+	if raw, ok := v.(rawValue); ok {
+		return string(raw)
+	}
+
+	var s string
+	if j, ok := v.(jsonText); ok {
+		s = string(j)
+	} else {
+		s = fmt.Sprint(fieldValue(v))
+	}
+
+	// values with control characters other than escape and invalid UTF-8 are quoted so they are escaped
+	if s == "" || strings.ContainsAny(s, " \t\r\n\"=") || strings.ContainsFunc(s, isUnsafe) || !utf8.ValidString(s) {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return s
+}
+
+// isUnsafe reports whether rune `r` is a control character which is escaped in field values.
+func isUnsafe(r rune) bool {
+	return r != '\x1b' && unicode.IsControl(r)
+}
+
+// clone returns copy of logger which shares output with original logger. Additional outputs, module levels
+// and styles are copied, so changing them does not affect original logger.
+func (l *Logger) clone() *Logger {
+	c := *l
+	c.fields = append([]Field(nil), l.fields...)
+	c.Outputs = slices.Clone(l.Outputs)
+	c.Modules = maps.Clone(l.Modules)
+	c.Styles = maps.Clone(l.Styles)
+	c.FieldStyles = maps.Clone(l.FieldStyles)
+	c.level = new(atomic.Int32)
+	c.level.Store(l.level.Load())
+
+	return &c
+}
+
+// With returns logger which records fields `fields` on each message, e.g. made by Dur or Err. Fields
+// override inherited fields of the same key: value of inherited field is replaced keeping its position.
+func (l *Logger) With(fields ...Field) *Logger {
+	c := l.clone()
+	c.fields = mergeFields(c.fields, fields)
+
+	return c
+}
+
+// Without returns logger which does not record inherited fields of keys `keys`. Removed fields can be added
+// back by With.
+func (l *Logger) Without(keys ...string) *Logger {
+	c := l.clone()
+	c.fields = slices.DeleteFunc(c.fields, func(f Field) bool { return slices.Contains(keys, f.Key) })
+
+	return c
+}
+
+// mergeFields returns fields `fields` with fields `override` appended or replacing values of fields of the
+// same key. Fields `fields` are modified.
+func mergeFields(fields, override []Field) []Field {
+	for _, f := range override {
+		i := slices.IndexFunc(fields, func(g Field) bool { return g.Key == f.Key })
+		if i < 0 {
+			fields = append(fields, f)
+			continue
+		}
+
+		fields[i].Value = f.Value
+	}
+
+	return fields
+}
+
+// WithWorker returns logger which records worker ID `id` on each message.
+func (l *Logger) WithWorker(id any) *Logger {
+	c := l.clone()
+	c.fields = mergeFields(c.fields, []Field{{"worker", id}})
+
+	return c
+}
+
+// Styled returns logger which renders text of terminal messages with style `style`, e.g. bold or boxed, for
+// individual important messages. Properties not set in style are inherited from level style.
+func (l *Logger) Styled(style lipgloss.Style) *Logger {
+	c := l.clone()
+	c.style = &style
+
+	return c
+}
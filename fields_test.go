@@ -0,0 +1,86 @@
+package simplelog
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestDerivedLoggerFields(t *testing.T) {
+	base := NewLogger(io.Discard).With(Field{"task", "old"}, Field{"user", "bob"}, Field{"worker", 1})
+
+	tests := []struct {
+		name   string
+		logger *Logger
+		want   []Field
+	}{
+		{"With overrides", base.With(Field{"user", "alice"}, Field{"id", 7}), []Field{{"task", "old"}, {"user", "alice"}, {"worker", 1}, {"id", 7}}},
+		{"With duplicates", base.With(Field{"id", 1}, Field{"id", 2}), []Field{{"task", "old"}, {"user", "bob"}, {"worker", 1}, {"id", 2}}},
+		{"WithWorker overrides", base.WithWorker(2), []Field{{"task", "old"}, {"user", "bob"}, {"worker", 2}}},
+		{"ForTask overrides", base.ForTask("new").Logger, []Field{{"task", "new"}, {"user", "bob"}, {"worker", 1}}},
+		{"ForTask twice", base.ForTask("a").ForTask("b").Logger, []Field{{"task", "b"}, {"user", "bob"}, {"worker", 1}}},
+		{"Without", base.Without("user", "missing"), []Field{{"task", "old"}, {"worker", 1}}},
+		{"Without all", base.Without("task", "user", "worker"), []Field{}},
+		{"Without then With", base.Without("user").With(Field{"user", "carol"}), []Field{{"task", "old"}, {"worker", 1}, {"user", "carol"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !reflect.DeepEqual(test.logger.fields, test.want) {
+				t.Errorf("got fields %v, want %v", test.logger.fields, test.want)
+			}
+		})
+	}
+
+	if want := []Field{{"task", "old"}, {"user", "bob"}, {"worker", 1}}; !reflect.DeepEqual(base.fields, want) {
+		t.Errorf("fields of parent logger are modified: %v", base.fields)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{"plain", "plain"},
+		{"a b", `"a b"`},
+		{"", `""`},
+		{1, "1"},
+		{jsonText(`{"a":1}`), `"{\"a\":1}"`},
+		{jsonText(`[1,2]`), "[1,2]"},
+		{rawValue(`a "b"`), `a "b"`},
+	}
+
+	for _, test := range tests {
+		if got := formatValue(test.v); got != test.want {
+			t.Errorf("formatValue(%#v) = %s, want %s", test.v, got, test.want)
+		}
+	}
+}
+
+func TestDerivedLoggerConfig(t *testing.T) {
+	base := NewLogger(io.Discard)
+	base.Outputs = make([]*Output, 0, 2)
+	base.Modules = map[string]LogLevel{"main": LogLevelDebug}
+
+	derived := base.With(Field{"id", 1})
+	derived.AddOutput(NewTextEncoder(), io.Discard)
+	derived.Modules["main"] = LogLevelTrace
+	derived.Styles[LogLevelInfo] = &defaultDebugStyle
+
+	other := base.With(Field{"id", 2})
+	other.AddOutput(NewJSONEncoder(), io.Discard)
+
+	if len(base.Outputs) != 0 {
+		t.Errorf("parent logger has %d outputs, want 0", len(base.Outputs))
+	}
+	if _, ok := derived.Outputs[0].Encoder.(*TextEncoder); !ok {
+		t.Error("output of derived logger is replaced by output of sibling logger")
+	}
+	if base.Modules["main"] != LogLevelDebug {
+		t.Errorf("module level of parent logger is %s, want debug", base.Modules["main"])
+	}
+	if _, exists := base.Styles[LogLevelInfo]; exists {
+		t.Error("style of parent logger is modified")
+	}
+}
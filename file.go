@@ -0,0 +1,294 @@
+package simplelog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// File is a log file writer with optional size-based and date-based rotation.
+type File struct {
+	// Path of log file. For date-stamped files it is a path of current file.
+	Path string
+
+	// Pattern is a path of date-stamped log file with `{date}` placeholder, e.g. `app-{date}.log`
+	Pattern string
+
+	// DateFormat is a format of date which replaces `{date}` placeholder of Pattern
+	DateFormat string
+
+	// UTC enables UTC dates in file names instead of local ones. It is set by OpenDailyFileUTC, so first
+	// file is named by UTC date too.
+	UTC bool
+
+	// Symlink is an optional path of symlink which points to current date-stamped log file
+	Symlink string
+
+	// MaxSize is a maximum size of log file in bytes before rotation. Zero value disables rotation.
+	MaxSize int64
+
+	// MaxBackups is a maximum number of rotated files `path.1`...`path.N` to keep. When it is zero, log file
+	// is truncated on rotation.
+	MaxBackups int
+
+	// opened file, it is nil after failed rotation until file is reopened by next write
+	f *os.File
+
+	// is file closed by Close
+	closed bool
+
+	// current file size
+	size int64
+
+	// stops retention cleanup goroutine
+	stopRetention func()
+
+	mu sync.Mutex
+
+	// mutex which prevents rotation and switching of date-stamped files during retention cleanup
+	cleanupMu sync.Mutex
+}
+
+// OpenFile opens log file `path` for appending, creating it if needed.
+func OpenFile(path string) (*File, error) {
+	file := &File{Path: path}
+
+	if err := file.open(); err != nil {
+		return nil, err
+	}
+
+	registerFile(file)
+
+	return file, nil
+}
+
+// OpenDailyFile opens date-stamped log file which is switched to new file when date changes. Pattern `pattern`
+// must contain `{date}` placeholder, e.g. `app-{date}.log`. Symlink `symlink` pointing to current file is
+// maintained if it is not empty.
+func OpenDailyFile(pattern, symlink string) (*File, error) {
+	return openDailyFile(pattern, symlink, false)
+}
+
+// OpenDailyFileUTC opens date-stamped log file like OpenDailyFile does, using UTC dates in file names.
+func OpenDailyFileUTC(pattern, symlink string) (*File, error) {
+	return openDailyFile(pattern, symlink, true)
+}
+
+// openDailyFile opens date-stamped log file using UTC dates if `utc` is true.
+func openDailyFile(pattern, symlink string, utc bool) (*File, error) {
+	if !strings.Contains(pattern, datePlaceholder) {
+		return nil, fmt.Errorf("log file pattern %q does not contain %s placeholder", pattern, datePlaceholder)
+	}
+
+	file := &File{
+		Pattern:    pattern,
+		DateFormat: defaultFileDateFormat,
+		UTC:        utc,
+		Symlink:    symlink}
+	file.Path = file.datedPath(time.Now())
+
+	if err := file.open(); err != nil {
+		return nil, err
+	}
+
+	if err := file.updateSymlink(); err != nil {
+		file.f.Close()
+		return nil, err
+	}
+
+	registerFile(file)
+
+	return file, nil
+}
+
+// datedPath returns path of date-stamped log file for time `t`.
+func (file *File) datedPath(t time.Time) string {
+	if file.UTC {
+		t = t.UTC()
+	}
+
+	return strings.ReplaceAll(file.Pattern, datePlaceholder, t.Format(file.DateFormat))
+}
+
+// switchTo closes current log file and opens file `path`. If file could not be opened, it is reopened by next
+// write. Must be called with locked mutex.
+func (file *File) switchTo(path string) error {
+	if file.f != nil {
+		err := file.f.Close()
+		file.f = nil
+		if err != nil {
+			return fmt.Errorf("close log file: %w", err)
+		}
+	}
+
+	file.cleanupMu.Lock()
+	file.Path = path
+	file.cleanupMu.Unlock()
+
+	if err := file.open(); err != nil {
+		return err
+	}
+
+	file.updateSymlink()
+
+	return nil
+}
+
+// updateSymlink points symlink to current log file.
+func (file *File) updateSymlink() error {
+	if file.Symlink == "" {
+		return nil
+	}
+
+	target := file.Path
+	if filepath.Dir(target) == filepath.Dir(file.Symlink) {
+		target = filepath.Base(target)
+	}
+
+	tmp := file.Symlink + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		return fmt.Errorf("create log file symlink: %w", err)
+	}
+
+	if err := os.Rename(tmp, file.Symlink); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("create log file symlink: %w", err)
+	}
+
+	return nil
+}
+
+// open opens log file. Must be called with locked mutex.
+func (file *File) open() error {
+	f, err := os.OpenFile(file.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	file.f = f
+	file.size = fi.Size()
+
+	return nil
+}
+
+// Write writes `p` to log file rotating it if size limit is reached. If log file could not be reopened after
+// rotation or switching to new date, it is reopened by next write.
+func (file *File) Write(p []byte) (n int, err error) {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.closed {
+		return 0, os.ErrClosed
+	}
+
+	if file.Pattern != "" {
+		if path := file.datedPath(time.Now()); path != file.Path {
+			if err := file.switchTo(path); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if file.f == nil {
+		if err := file.open(); err != nil {
+			return 0, err
+		}
+		file.updateSymlink()
+	}
+
+	if file.MaxSize > 0 && file.size > 0 && file.size+int64(len(p)) > file.MaxSize {
+		if err := file.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = file.f.Write(p)
+	file.size += int64(n)
+
+	return n, err
+}
+
+// rotate renames current log file to `path.1` shifting older backups and opens new one. If no backups are
+// kept, log file is truncated instead. Must be called with locked mutex.
+func (file *File) rotate() error {
+	if file.MaxBackups <= 0 {
+		if err := file.f.Truncate(0); err != nil {
+			return fmt.Errorf("rotate log file: %w", err)
+		}
+		file.size = 0
+
+		return nil
+	}
+
+	err := file.f.Close()
+	file.f = nil
+	if err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+
+	file.cleanupMu.Lock()
+	defer file.cleanupMu.Unlock()
+
+	os.Remove(backupName(file.Path, file.MaxBackups))
+	os.Remove(backupName(file.Path, file.MaxBackups) + compressedExt)
+	for i := file.MaxBackups - 1; i >= 1; i-- {
+		os.Rename(backupName(file.Path, i), backupName(file.Path, i+1))
+		os.Rename(backupName(file.Path, i)+compressedExt, backupName(file.Path, i+1)+compressedExt)
+	}
+
+	if err := os.Rename(file.Path, backupName(file.Path, 1)); err != nil {
+		// file is reopened and rotation is retried by next write
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+
+	return file.open()
+}
+
+// Close closes log file and stops retention cleanup.
+func (file *File) Close() error {
+	unregisterFile(file)
+	file.SetRetention(Retention{})
+
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	file.closed = true
+	if file.f == nil {
+		return nil
+	}
+
+	err := file.f.Close()
+	file.f = nil
+
+	return err
+}
+
+// backupName returns name of `n`-th backup of log file `path`.
+func backupName(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// Sync commits written data to disk.
+func (file *File) Sync() error {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.closed {
+		return os.ErrClosed
+	}
+	if file.f == nil {
+		return nil
+	}
+
+	return file.f.Sync()
+}
@@ -0,0 +1,136 @@
+package simplelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileRotateWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	file.MaxSize = 10
+
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{"0123456789", "abc"} {
+		if _, err := file.Write([]byte(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(before, after) {
+		t.Error("log file is replaced by rotation")
+	}
+	if b, _ := os.ReadFile(path); string(b) != "abc" {
+		t.Errorf("log file contains %q after rotation, want %q", b, "abc")
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Errorf("backup is created: %v", err)
+	}
+}
+
+func TestFileRotateFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	file.MaxSize = 10
+	file.MaxBackups = 1
+
+	// backup can not be replaced by rename while it is non-empty directory
+	if err := os.MkdirAll(filepath.Join(path+".1", "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := file.Write([]byte("0123456789")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte("abc")); err == nil {
+		t.Fatal("rotation did not fail")
+	}
+
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte("abc")); err != nil {
+		t.Fatalf("write after failed rotation: %v", err)
+	}
+
+	if b, _ := os.ReadFile(path); string(b) != "abc" {
+		t.Errorf("log file contains %q, want %q", b, "abc")
+	}
+	if b, _ := os.ReadFile(path + ".1"); string(b) != "0123456789" {
+		t.Errorf("backup contains %q, want %q", b, "0123456789")
+	}
+}
+
+func TestFileSwitchFailure(t *testing.T) {
+	dir := t.TempDir()
+	file, err := OpenFile(filepath.Join(dir, "old.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// file can not be opened while its path is a directory
+	path := filepath.Join(dir, "new.log")
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := file.switchTo(path); err == nil {
+		t.Fatal("switch did not fail")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte("abc")); err != nil {
+		t.Fatalf("write after failed switch: %v", err)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "abc" {
+		t.Errorf("log file contains %q, want %q", b, "abc")
+	}
+}
+
+func TestOpenDailyFileUTC(t *testing.T) {
+	// local zone is chosen so local date differs from UTC one
+	now := time.Now().UTC()
+	offset := 13 * 60 * 60
+	if now.Hour() < 12 {
+		offset = -offset
+	}
+	local := time.Local
+	time.Local = time.FixedZone("test", offset)
+	defer func() { time.Local = local }()
+
+	dir := t.TempDir()
+	file, err := OpenDailyFileUTC(filepath.Join(dir, "app-{date}.log"), filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	want := filepath.Join(dir, "app-"+time.Now().UTC().Format(defaultFileDateFormat)+".log")
+	if file.Path != want {
+		t.Errorf("opened %s, want %s", file.Path, want)
+	}
+	if target, err := os.Readlink(filepath.Join(dir, "app.log")); err != nil || target != want && target != filepath.Base(want) {
+		t.Errorf("symlink points to %q (%v), want %s", target, err, want)
+	}
+}
@@ -0,0 +1,96 @@
+package simplelog
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Query defines conditions of entries selection. Zero values of conditions match all entries.
+type Query struct {
+	// MinLevel is a minimum log level of entries
+	MinLevel LogLevel
+
+	// MaxLevel is a maximum log level of entries. Nil value means no upper limit.
+	MaxLevel *LogLevel
+
+	// Since is a minimum time of entries, inclusive
+	Since time.Time
+
+	// Until is a maximum time of entries, exclusive
+	Until time.Time
+
+	// Source is a required source of entries
+	Source string
+
+	// Message is a regular expression which entry message must match
+	Message *regexp.Regexp
+
+	// Fields are matchers which all must match entry fields
+	Fields []FieldMatcher
+}
+
+// FieldMatcher matches entry field by key and optionally by value
+type FieldMatcher struct {
+	// Key of field
+	Key string
+
+	// Value is a regular expression which string representation of field value must match. Nil value means
+	// that field only must exist.
+	Value *regexp.Regexp
+}
+
+// Match reports whether entry `e` matches query.
+func (q *Query) Match(e *Entry) bool {
+	if e.Level < q.MinLevel || (q.MaxLevel != nil && e.Level > *q.MaxLevel) {
+		return false
+	}
+
+	if (!q.Since.IsZero() && e.Time.Before(q.Since)) || (!q.Until.IsZero() && !e.Time.Before(q.Until)) {
+		return false
+	}
+
+	if q.Source != "" && e.Source != q.Source {
+		return false
+	}
+
+	if q.Message != nil && !q.Message.MatchString(e.Message) {
+		return false
+	}
+
+	for _, m := range q.Fields {
+		if !m.match(e.Fields) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// match reports whether any of fields `fields` matches matcher.
+func (m FieldMatcher) match(fields []Field) bool {
+	for _, f := range fields {
+		if f.Key != m.Key {
+			continue
+		}
+
+		if m.Value == nil || m.Value.MatchString(fmt.Sprint(f.Value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterEntries returns entries of `entries` which match query `q`.
+func FilterEntries(entries []Entry, q Query) []Entry {
+	var result []Entry
+
+	for i := range entries {
+		if q.Match(&entries[i]) {
+			result = append(result, entries[i])
+		}
+	}
+
+	return result
+}
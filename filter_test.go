@@ -0,0 +1,49 @@
+package simplelog
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFilterEntries(t *testing.T) {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Time: start, Level: LogLevelTrace, Message: "trace"},
+		{Time: start.Add(time.Minute), Level: LogLevelDebug, Message: "debug", Fields: []Field{{"user", "bob"}}},
+		{Time: start.Add(2 * time.Minute), Level: LogLevelInfo, Message: "request done", Source: "api", Fields: []Field{{"status", 200}}},
+		{Time: start.Add(3 * time.Minute), Level: LogLevelError, Message: "request failed", Source: "api", Fields: []Field{{"status", 500}}},
+	}
+
+	trace, debug := LogLevelTrace, LogLevelDebug
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{"all", Query{}, []string{"trace", "debug", "request done", "request failed"}},
+		{"min level", Query{MinLevel: LogLevelInfo}, []string{"request done", "request failed"}},
+		{"max level trace", Query{MaxLevel: &trace}, []string{"trace"}},
+		{"level range", Query{MinLevel: LogLevelTrace, MaxLevel: &debug}, []string{"trace", "debug"}},
+		{"time window", Query{Since: start.Add(time.Minute), Until: start.Add(3 * time.Minute)}, []string{"debug", "request done"}},
+		{"source", Query{Source: "api"}, []string{"request done", "request failed"}},
+		{"message", Query{Message: regexp.MustCompile(`^request`)}, []string{"request done", "request failed"}},
+		{"field exists", Query{Fields: []FieldMatcher{{Key: "user"}}}, []string{"debug"}},
+		{"field value", Query{Fields: []FieldMatcher{{Key: "status", Value: regexp.MustCompile(`^5`)}}}, []string{"request failed"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, e := range FilterEntries(entries, test.query) {
+				got = append(got, e.Message)
+			}
+
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package simplelog
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// fingerprintFields returns `fingerprint` field of Error and Fatal messages if fingerprinting is enabled and
+// message is not filtered out by level. Fingerprint is a hash of message template returned by `template` and
+// types of errors among arguments `args`; template is made only if fingerprint is needed.
+func (l *Logger) fingerprintFields(logLevel LogLevel, template func() string, args []any) []Field {
+	if !l.Fingerprint || logLevel < LogLevelError || logLevel == LogLevelProgress || !l.enabled(logLevel) {
+		return nil
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(template()))
+
+	for _, arg := range args {
+		err, ok := arg.(error)
+		for ok && err != nil {
+			fmt.Fprintf(h, "\x00%T", err)
+			err = errors.Unwrap(err)
+		}
+	}
+
+	return []Field{{"fingerprint", strconv.FormatUint(h.Sum64(), 16)}}
+}
+
+// messageTemplate returns template of message made of arguments `args` by replacing errors with `%v` and
+// numbers with `#`.
+func messageTemplate(args []any) string {
+	sb := new(strings.Builder)
+	for _, arg := range args {
+		if _, ok := arg.(error); ok {
+			sb.WriteString("%v")
+			continue
+		}
+
+		sb.WriteString(fmt.Sprint(arg))
+	}
+
+	return normalizeTemplate(sb.String())
+}
+
+// normalizeTemplate replaces runs of digits in message `s` with `#`.
+func normalizeTemplate(s string) string {
+	sb := new(strings.Builder)
+
+	digits := false
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			if !digits {
+				sb.WriteRune('#')
+			}
+			digits = true
+			continue
+		}
+
+		digits = false
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
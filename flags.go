@@ -0,0 +1,194 @@
+package simplelog
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// RegisterFlags registers logging flags in flag set `fs`:
+//
+//	-log-level  minimum log level
+//	-log-format format of non-terminal output
+//	-log-file   path of log file
+//	-no-color   disable colors
+//	-q          print only warnings and errors
+//	-v, -vv     print debug or trace messages, -v may be repeated
+//
+// Level flags are applied in command line order: -log-level and -q set level, each -v lowers current level by
+// one step, so `-q -v` prints Info messages and `-v -log-level=warn` prints Warn ones. If -log-file is
+// repeated, last file is used and previous ones are closed.
+func (l *Logger) RegisterFlags(fs *flag.FlagSet) {
+	fs.Var(&levelFlag{l}, "log-level", "minimum log level: trace, debug, info, warn, error or fatal")
+	fs.Var(&l.Format, "log-format", "format of log messages: text, json or container")
+	fs.Var(&fileFlag{logger: l}, "log-file", "write log messages to file")
+	fs.BoolVar(&l.NoColor, "no-color", l.NoColor, "disable colors")
+
+	v := &verbosityFlag{logger: l}
+	fs.Var(&quietFlag{l}, "q", "print only warnings and errors")
+	fs.Var(v, "v", "print debug messages, repeat to print trace messages")
+	fs.Var(&verbosityDeltaFlag{v, 2}, "vv", "print trace messages")
+}
+
+// levelFlag is a flag which sets minimum log level of logger
+type levelFlag struct {
+	logger *Logger
+}
+
+func (f *levelFlag) String() string {
+	if f == nil || f.logger == nil {
+		return defaulLogLevel.String()
+	}
+
+	return f.logger.Level().String()
+}
+
+func (f *levelFlag) Set(s string) error {
+	level, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+
+	f.logger.SetLevel(level)
+
+	return nil
+}
+
+// Type implements pflag.Value.
+func (f *levelFlag) Type() string {
+	return "level"
+}
+
+// fileFlag is a flag which redirects logger output to file
+type fileFlag struct {
+	logger *Logger
+	path   string
+
+	// file opened by last occurrence of flag
+	file *File
+}
+
+func (f *fileFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.path
+}
+
+func (f *fileFlag) Type() string {
+	return "path"
+}
+
+func (f *fileFlag) Set(path string) error {
+	file, err := OpenFile(path)
+	if err != nil {
+		return err
+	}
+
+	l := f.logger
+	l.mu.Lock()
+	l.clearProgress()
+	l.setWriter(file)
+	l.Writer = file
+	l.mu.Unlock()
+
+	prev := f.file
+	f.path, f.file = path, file
+
+	if prev != nil {
+		if err := prev.Close(); err != nil {
+			return fmt.Errorf("close log file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// verbosityFlag is a counting flag which lowers minimum log level by one step on each occurrence
+type verbosityFlag struct {
+	logger *Logger
+	n      int
+}
+
+func (f *verbosityFlag) String() string {
+	if f == nil {
+		return "0"
+	}
+
+	return strconv.Itoa(f.n)
+}
+
+func (f *verbosityFlag) Set(s string) error {
+	ok, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		f.add(1)
+	}
+
+	return nil
+}
+
+func (f *verbosityFlag) IsBoolFlag() bool {
+	return true
+}
+
+// add increases verbosity by `delta` lowering current logger level by the same number of steps.
+func (f *verbosityFlag) add(delta int) {
+	f.n += delta
+	f.logger.SetLevel(max(f.logger.Level()-LogLevel(delta), LogLevelTrace))
+}
+
+// verbosityDeltaFlag is a boolean flag which changes verbosity by fixed value
+type verbosityDeltaFlag struct {
+	v     *verbosityFlag
+	delta int
+}
+
+func (f *verbosityDeltaFlag) String() string {
+	return "false"
+}
+
+func (f *verbosityDeltaFlag) Set(s string) error {
+	ok, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		f.v.add(f.delta)
+	}
+
+	return nil
+}
+
+func (f *verbosityDeltaFlag) IsBoolFlag() bool {
+	return true
+}
+
+// quietFlag is a boolean flag which enables quiet mode
+type quietFlag struct {
+	logger *Logger
+}
+
+func (f *quietFlag) String() string {
+	return "false"
+}
+
+func (f *quietFlag) Set(s string) error {
+	ok, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+
+	f.logger.Quiet(ok)
+
+	return nil
+}
+
+func (f *quietFlag) IsBoolFlag() bool {
+	return true
+}
@@ -0,0 +1,76 @@
+package simplelog
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegisterFlagsLevel(t *testing.T) {
+	tests := []struct {
+		args []string
+		want LogLevel
+	}{
+		{nil, LogLevelInfo},
+		{[]string{"-v"}, LogLevelDebug},
+		{[]string{"-v", "-v"}, LogLevelTrace},
+		{[]string{"-vv"}, LogLevelTrace},
+		{[]string{"-vv", "-v"}, LogLevelTrace},
+		{[]string{"-q"}, LogLevelWarn},
+		{[]string{"-q", "-v"}, LogLevelInfo},
+		{[]string{"-v", "-q"}, LogLevelWarn},
+		{[]string{"-log-level", "error", "-v"}, LogLevelWarn},
+		{[]string{"-v", "-log-level", "error"}, LogLevelError},
+		{[]string{"-v", "-log-level", "warn", "-v"}, LogLevelInfo},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			l := NewLogger(io.Discard)
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			l.RegisterFlags(fs)
+
+			if err := fs.Parse(test.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := l.Level(); got != test.want {
+				t.Errorf("level is %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRegisterFlagsFile(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+
+	l := NewLogger(io.Discard)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l.RegisterFlags(fs)
+
+	if err := fs.Parse([]string{"-log-file", first}); err != nil {
+		t.Fatal(err)
+	}
+	opened := fs.Lookup("log-file").Value.(*fileFlag).file
+
+	if err := fs.Parse([]string{"-log-file", second}); err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Lookup("log-file").Value.(*fileFlag).file.Close()
+
+	if _, err := opened.Write([]byte("message\n")); err == nil {
+		t.Error("file of replaced flag value is not closed")
+	}
+
+	l.Info("message")
+
+	if b, _ := os.ReadFile(first); len(b) != 0 {
+		t.Errorf("message is written to replaced file: %q", b)
+	}
+	if b, _ := os.ReadFile(second); !strings.Contains(string(b), "|INF| message") {
+		t.Errorf("log file contains %q", b)
+	}
+}
@@ -0,0 +1,75 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format defines format of messages written to non-terminal output
+type Format int
+
+const (
+	// FormatText is a plain text format with `|INF|`-like level prefixes
+	FormatText Format = iota
+
+	// FormatJSON is a format with single JSON object per line
+	FormatJSON
+
+	// FormatContainer is a JSON format suitable for container log drivers, see ContainerEncoder
+	FormatContainer
+)
+
+// String returns name of format.
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	case FormatContainer:
+		return "container"
+	}
+
+	return fmt.Sprintf("format(%d)", int(f))
+}
+
+// ParseFormat returns format by its name.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "text", "":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	case "container":
+		return FormatContainer, nil
+	}
+
+	return 0, fmt.Errorf("unknown log format: %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Format) UnmarshalText(text []byte) error {
+	format, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = format
+
+	return nil
+}
+
+// Set implements flag.Value and pflag.Value.
+func (f *Format) Set(s string) error {
+	return f.UnmarshalText([]byte(s))
+}
+
+// Type implements pflag.Value.
+func (f Format) Type() string {
+	return "format"
+}
@@ -0,0 +1,88 @@
+package simplelog
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// formatter converts field value to rendered value
+type formatter struct {
+	typ reflect.Type
+	f   func(v any) any
+}
+
+// formatters contains registered field value formatters. Formatters of concrete types have priority over
+// formatters of interfaces, which are tried in order of registration.
+var formatters = struct {
+	types      map[reflect.Type]func(v any) any
+	interfaces []formatter
+	mu         sync.RWMutex
+}{types: make(map[reflect.Type]func(v any) any)}
+
+func init() {
+	RegisterFormatter(func(d time.Duration) any { return d.String() })
+	RegisterFormatter(func(t time.Time) any { return t.Format(time.RFC3339Nano) })
+	RegisterFormatter(func(b []byte) any { return hex.EncodeToString(b) })
+	RegisterFormatter(func(err error) any { return err.Error() })
+	RegisterFormatter(func(s fmt.Stringer) any { return s.String() })
+}
+
+// RegisterFormatter registers formatter `f` of field values of type `T` used by all encoders. Value returned
+// by formatter is rendered instead of original one: it is printed by text encoders and marshalled by JSON
+// encoders. If `T` is an interface, formatter is used for all values implementing it which do not have
+// formatter of their own type. Formatter replaces previously registered formatter of the same type.
+//
+// Default formatters render time.Duration and fmt.Stringer values as strings, time.Time as RFC 3339
+// timestamps, []byte as hex and errors as their messages.
+func RegisterFormatter[T any](f func(v T) any) {
+	typ := reflect.TypeFor[T]()
+	wrapped := func(v any) any { return f(v.(T)) }
+
+	formatters.mu.Lock()
+	defer formatters.mu.Unlock()
+
+	if typ.Kind() != reflect.Interface {
+		formatters.types[typ] = wrapped
+		return
+	}
+
+	for i := range formatters.interfaces {
+		if formatters.interfaces[i].typ == typ {
+			formatters.interfaces[i].f = wrapped
+			return
+		}
+	}
+
+	formatters.interfaces = append(formatters.interfaces, formatter{typ, wrapped})
+}
+
+// fieldValue returns value of field `v` converted by registered formatter.
+func fieldValue(v any) any {
+	if s, ok := v.(semanticValue); ok {
+		v = s.value
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	typ := reflect.TypeOf(v)
+
+	formatters.mu.RLock()
+	defer formatters.mu.RUnlock()
+
+	if f, exists := formatters.types[typ]; exists {
+		return f(v)
+	}
+
+	for _, formatter := range formatters.interfaces {
+		if typ.Implements(formatter.typ) {
+			return formatter.f(v)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,57 @@
+package simplelog
+
+import (
+	"sync"
+	"time"
+)
+
+// fatalHooks holds functions called before exit by Fatal methods, shared by all loggers derived from logger
+type fatalHooks struct {
+	funcs []func()
+
+	mu sync.Mutex
+}
+
+// OnFatal registers function `f` which is called by Fatal methods before program exit, e.g. to remove PID
+// file or roll back transaction. Functions are called in reverse order of registration; exit is not delayed
+// longer than FatalHookTimeout by all of them.
+func (l *Logger) OnFatal(f func()) {
+	l.hooks.mu.Lock()
+	l.hooks.funcs = append(l.hooks.funcs, f)
+	l.hooks.mu.Unlock()
+}
+
+// runFatalHooks calls functions registered by OnFatal in reverse order waiting them for FatalHookTimeout.
+func (l *Logger) runFatalHooks() {
+	l.hooks.mu.Lock()
+	funcs := append([]func(){}, l.hooks.funcs...)
+	l.hooks.mu.Unlock()
+
+	if len(funcs) == 0 {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := len(funcs) - 1; i >= 0; i-- {
+			callHook(funcs[i])
+		}
+	}()
+
+	timer := time.NewTimer(l.FatalHookTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+}
+
+// callHook calls function `f` recovering its panic, so next hooks are still called.
+func callHook(f func()) {
+	defer func() { recover() }()
+
+	f()
+}
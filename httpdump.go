@@ -0,0 +1,171 @@
+package simplelog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+// maxDumpBodyBytes is a maximum number of body bytes written by DumpRequest and DumpResponse
+const maxDumpBodyBytes = 4096
+
+// redactedHeaders are headers whose values are hidden by DumpRequest and DumpResponse
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// DumpRequest writes message of log level `logLevel` with dump of HTTP request `req` made by httputil, e.g.
+// for API debugging. Incoming server requests and outgoing client requests are both supported. Authorization
+// and cookie headers and query parameters with names like `token` or `password` are redacted. If `body` is
+// true, up to 4 KiB of body are included; only they are read and body of request is preserved. Terminal shows
+// dump below message; other outputs record it as `dump` field.
+func (l *Logger) DumpRequest(logLevel LogLevel, req *http.Request, body bool) (n int, err error) {
+	if !l.enabled(logLevel) {
+		return 0, nil
+	}
+
+	var head []byte
+	if body && req.Body != nil && req.Body != http.NoBody {
+		head, req.Body, err = peekBody(req.Body)
+		if err != nil {
+			return 0, fmt.Errorf("dump request: %w", err)
+		}
+	}
+
+	r := *req
+	r.Header = redactHeaders(req.Header)
+	r.URL = redactURL(req.URL)
+
+	var b []byte
+	if req.RequestURI == "" {
+		// outgoing request is dumped as it is sent by client
+		b, err = httputil.DumpRequestOut(&r, false)
+	} else {
+		path, query, _ := strings.Cut(req.RequestURI, "?")
+		r.RequestURI = joinQuery(path, redactQuery(query))
+		b, err = httputil.DumpRequest(&r, false)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("dump request: %w", err)
+	}
+
+	return l.dump(logLevel, fmt.Sprintf("request %s %s", req.Method, r.URL.Redacted()), b, head, req.ContentLength)
+}
+
+// DumpResponse writes message of log level `logLevel` with dump of HTTP response `resp` like DumpRequest
+// does.
+func (l *Logger) DumpResponse(logLevel LogLevel, resp *http.Response, body bool) (n int, err error) {
+	if !l.enabled(logLevel) {
+		return 0, nil
+	}
+
+	var head []byte
+	if body && resp.Body != nil && resp.Body != http.NoBody {
+		head, resp.Body, err = peekBody(resp.Body)
+		if err != nil {
+			return 0, fmt.Errorf("dump response: %w", err)
+		}
+	}
+
+	r := *resp
+	r.Header = redactHeaders(resp.Header)
+
+	b, err := httputil.DumpResponse(&r, false)
+	if err != nil {
+		return 0, fmt.Errorf("dump response: %w", err)
+	}
+
+	return l.dump(logLevel, "response "+resp.Status, b, head, resp.ContentLength)
+}
+
+// peekBody reads first bytes of body `rc` to dump and returns them with body which reads the whole body
+// again.
+func peekBody(rc io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	head, err := io.ReadAll(io.LimitReader(rc, maxDumpBodyBytes+1))
+
+	return head, &struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), rc), rc}, err
+}
+
+// dump writes message `s` with HTTP dump of headers `b` and first bytes of body `body` of total size `size`
+// which is not positive if it is unknown.
+func (l *Logger) dump(logLevel LogLevel, s string, b, body []byte, size int64) (n int, err error) {
+	if len(body) > maxDumpBodyBytes {
+		marker := l.TrimMarker
+		if size > 0 {
+			marker = fmt.Sprintf("%s(%d bytes)", l.TrimMarker, size)
+		}
+		// body is shared with reader of preserved body, so it is copied by append
+		i := cutIndex(string(body), maxDumpBodyBytes)
+		body = append(body[:i:i], marker...)
+	}
+	b = append(b, body...)
+
+	dump := strings.TrimRight(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n")
+	fields := []Field{{"dump", dump}}
+
+	if !l.terminal() {
+		return l.log(logLevel, s, "", fields)
+	}
+
+	dump = sanitize(dump, l.Sanitize)
+	if l.colored() {
+		dump = l.FieldStyle.Render(dump)
+	}
+
+	return l.log(logLevel, s, sanitize(s, l.Sanitize)+"\n"+dump, fields)
+}
+
+// redactHeaders returns copy of headers `h` with values of sensitive headers replaced.
+func redactHeaders(h http.Header) http.Header {
+	h = h.Clone()
+	for _, key := range redactedHeaders {
+		if _, exists := h[key]; exists {
+			h.Set(key, "[redacted]")
+		}
+	}
+
+	return h
+}
+
+// redactURL returns copy of URL `u` with values of sensitive query parameters replaced.
+func redactURL(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+
+	c := *u
+	c.RawQuery = redactQuery(u.RawQuery)
+
+	return &c
+}
+
+// redactQuery returns raw query `query` with values of parameters with sensitive names replaced.
+func redactQuery(query string) string {
+	if query == "" {
+		return ""
+	}
+
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && sensitiveName.MatchString(name) {
+			params[i] = key + "=[redacted]"
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+// joinQuery returns path `path` with raw query `query` if it is not empty.
+func joinQuery(path, query string) string {
+	if query == "" {
+		return path
+	}
+
+	return path + "?" + query
+}
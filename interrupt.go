@@ -0,0 +1,67 @@
+package simplelog
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// showCursor is escape sequence which restores cursor hidden by progress renderers
+const showCursor = "\x1b[?25h"
+
+// HandleInterrupts installs SIGINT and SIGTERM handler which clears active progress line, restores cursor,
+// writes Warn message `interrupted`, syncs writers and closes sinks to flush their buffers before re-raising
+// the signal, so interrupted program does not leave half-drawn progress on terminal. Returned function uninstalls handler.
+func (l *Logger) HandleInterrupts() (stop func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-c:
+			l.interrupted()
+
+			signal.Stop(c)
+			if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+				return
+			}
+			os.Exit(1)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(c)
+			close(done)
+		})
+	}
+}
+
+// interrupted clears progress line and writes interruption message.
+func (l *Logger) interrupted() {
+	l.mu.Lock()
+	if l.terminal() {
+		l.writer().Write([]byte(showCursor))
+	}
+	l.clearProgress()
+	l.mu.Unlock()
+
+	l.p(LogLevelWarn, "interrupted")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	syncWriter(l.writer())
+	for _, output := range l.Outputs {
+		if output.Writer != nil {
+			syncWriter(output.Writer)
+		}
+		if c, ok := output.Sink.(interface{ Close() error }); ok {
+			c.Close()
+		}
+	}
+}
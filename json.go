@@ -0,0 +1,101 @@
+package simplelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	jsonKeyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#80c0ff"))
+	jsonStringStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#80ff80"))
+	jsonNumberStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffc080"))
+	jsonLiteralStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff80ff"))
+)
+
+// jsonText is a field value which is valid JSON. JSON encoders embed it as is and text encoders quote it
+// like other string values.
+type jsonText string
+
+// MarshalJSON implements json.Marshaler.
+func (j jsonText) MarshalJSON() ([]byte, error) {
+	return []byte(j), nil
+}
+
+// JSON writes message `label` of log level `logLevel` with value `v` marshalled to JSON. Terminal shows
+// indented JSON with syntax highlighting below label; other outputs record compact JSON as `json` field.
+func (l *Logger) JSON(logLevel LogLevel, label string, v any) (n int, err error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0, fmt.Errorf("marshal %s: %w", label, err)
+	}
+
+	fields := []Field{{"json", jsonText(b)}}
+
+	if !l.terminal() {
+		return l.log(logLevel, label, "", fields)
+	}
+
+	buf := new(bytes.Buffer)
+	json.Indent(buf, b, "", "  ")
+
+	s := buf.String()
+	if l.colored() {
+		s = highlightJSON(s)
+	}
+
+	return l.log(logLevel, label, sanitize(label, l.Sanitize)+"\n"+s, fields)
+}
+
+// highlightJSON returns JSON `s` with keys, strings, numbers and literals colored.
+func highlightJSON(s string) string {
+	sb := new(strings.Builder)
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(s))
+
+			style := jsonStringStyle
+			if rest := strings.TrimLeft(s[end:], " \t\n"); strings.HasPrefix(rest, ":") {
+				style = jsonKeyStyle
+			}
+
+			sb.WriteString(style.Render(s[i:end]))
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(s) && strings.IndexByte("0123456789.eE+-", s[end]) >= 0 {
+				end++
+			}
+
+			sb.WriteString(jsonNumberStyle.Render(s[i:end]))
+			i = end
+		case c == 't' || c == 'f' || c == 'n':
+			end := i + 1
+			for end < len(s) && s[end] >= 'a' && s[end] <= 'z' {
+				end++
+			}
+
+			sb.WriteString(jsonLiteralStyle.Render(s[i:end]))
+			i = end
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+
+	return sb.String()
+}
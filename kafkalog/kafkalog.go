@@ -0,0 +1,90 @@
+// Package kafkalog provides simplelog sink which publishes entries to Kafka topic.
+//
+// Package does not depend on any Kafka client: Producer is usually a thin adapter of client library, e.g.
+// of kafka.Writer from github.com/segmentio/kafka-go:
+//
+//	type producer struct{ w *kafka.Writer }
+//
+//	func (p producer) Produce(ctx context.Context, messages []kafkalog.Message) error {
+//		msgs := make([]kafka.Message, len(messages))
+//		for i, m := range messages {
+//			msgs[i] = kafka.Message{Topic: m.Topic, Key: m.Key, Value: m.Value, Time: m.Time}
+//		}
+//		return p.w.WriteMessages(ctx, msgs...)
+//	}
+package kafkalog
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/nxshock/simplelog"
+)
+
+// Message is a Kafka message
+type Message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+	Time  time.Time
+}
+
+// Producer publishes messages to Kafka
+type Producer interface {
+	// Produce publishes batch of messages and returns delivery error.
+	Produce(ctx context.Context, messages []Message) error
+}
+
+// Sink is a simplelog sink which publishes entries encoded as JSON to Kafka topic. Entries are buffered and
+// published in batches, delivery errors are reported through logger ErrorHandler.
+type Sink struct {
+	*simplelog.BatchSink
+
+	// Encoder of message values. It must not be changed after first entry is written.
+	Encoder simplelog.Encoder
+
+	// Key returns key of message for entry. Messages have no keys if it is nil. It must not be changed after
+	// first entry is written.
+	Key func(e *simplelog.Entry) []byte
+
+	producer Producer
+	topic    string
+}
+
+// NewSink returns new sink which publishes entries to topic `topic` using producer `producer`.
+func NewSink(producer Producer, topic string, options simplelog.BatchOptions) *Sink {
+	s := &Sink{
+		Encoder:  simplelog.NewJSONEncoder(),
+		producer: producer,
+		topic:    topic}
+
+	s.BatchSink = simplelog.NewBatchSink(s.flush, options)
+
+	return s
+}
+
+// flush publishes batch of entries.
+func (s *Sink) flush(ctx context.Context, batch []*simplelog.Entry) error {
+	messages := make([]Message, 0, len(batch))
+
+	for _, e := range batch {
+		value, err := s.Encoder.Encode(e)
+		if err != nil {
+			return err
+		}
+
+		message := Message{
+			Topic: s.topic,
+			Value: bytes.TrimSuffix(value, []byte{'\n'}),
+			Time:  e.Time}
+
+		if s.Key != nil {
+			message.Key = s.Key(e)
+		}
+
+		messages = append(messages, message)
+	}
+
+	return s.producer.Produce(ctx, messages)
+}
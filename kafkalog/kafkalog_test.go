@@ -0,0 +1,86 @@
+package kafkalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nxshock/simplelog"
+)
+
+// producer is a producer which records published messages. It fails while `err` is set.
+type producer struct {
+	mu       sync.Mutex
+	err      error
+	messages []Message
+}
+
+func (p *producer) Produce(ctx context.Context, messages []Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, messages...)
+
+	return nil
+}
+
+func TestSink(t *testing.T) {
+	p := new(producer)
+	s := NewSink(p, "logs", simplelog.BatchOptions{Size: 10, Interval: time.Hour})
+	s.Key = func(e *simplelog.Entry) []byte { return []byte(e.Source) }
+
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	messages := []string{"a", "b"}
+	for _, message := range messages {
+		if err := s.WriteEntry(&simplelog.Entry{Time: now, Level: simplelog.LogLevelInfo, Source: "api", Message: message}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(p.messages) != 2 {
+		t.Fatalf("published %d messages, want 2", len(p.messages))
+	}
+	for i, m := range p.messages {
+		if m.Topic != "logs" || string(m.Key) != "api" || !m.Time.Equal(now) {
+			t.Errorf("published message to topic %q with key %q and time %s", m.Topic, m.Key, m.Time)
+		}
+		if value := string(m.Value); strings.HasSuffix(value, "\n") || !strings.Contains(value, `"msg":"`+messages[i]+`"`) {
+			t.Errorf("published value %q", value)
+		}
+	}
+}
+
+func TestSinkProduceError(t *testing.T) {
+	p := &producer{err: errors.New("broker unavailable")}
+	s := NewSink(p, "logs", simplelog.BatchOptions{Size: 1, Interval: time.Hour, MaxRetries: -1})
+
+	errs := make(chan error, 1)
+	s.SetErrorHandler(func(err error) { errs <- err })
+
+	if err := s.WriteEntry(&simplelog.Entry{Time: time.Now(), Level: simplelog.LogLevelInfo, Message: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, p.err) {
+			t.Errorf("got error %v, want %v", err, p.err)
+		}
+	case <-time.After(time.Second):
+		t.Error("delivery error is not reported")
+	}
+
+	s.Close()
+	if got := s.Dropped(); got != 1 {
+		t.Errorf("dropped %d entries, want 1", got)
+	}
+}
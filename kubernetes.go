@@ -0,0 +1,50 @@
+package simplelog
+
+import "os"
+
+// kubernetesEnvFields maps downward API environment variables to field keys
+var kubernetesEnvFields = []struct {
+	env, key string
+}{
+	{"POD_NAME", "pod"},
+	{"POD_NAMESPACE", "namespace"},
+	{"NODE_NAME", "node"},
+}
+
+// kubernetesSeverities are names of log levels used by Kubernetes log collectors
+var kubernetesSeverities = map[LogLevel]string{
+	LogLevelTrace: "DEBUG",
+	LogLevelDebug: "DEBUG",
+	LogLevelInfo:  "INFO",
+	LogLevelWarn:  "WARNING",
+	LogLevelError: "ERROR",
+	LogLevelFatal: "CRITICAL",
+}
+
+// NewKubernetesEncoder returns JSON encoder with UTC timestamps and `severity` key of log level.
+func NewKubernetesEncoder() *JSONEncoder {
+	enc := NewJSONEncoder()
+	enc.UTC = true
+	enc.LevelNames = kubernetesSeverities
+	enc.Rename = map[string]string{"level": "severity", "msg": "message"}
+
+	return enc
+}
+
+// NewForKubernetes returns logger which writes JSON messages to standard output with UTC timestamps and
+// `severity` field and without colors and progress messages. Pod, namespace and node fields are added
+// from POD_NAME, POD_NAMESPACE and NODE_NAME environment variables when present.
+func NewForKubernetes() *Logger {
+	logger := NewLogger(os.Stdout)
+	logger.Encoder = NewKubernetesEncoder()
+	logger.NoColor = true
+	logger.NoProgress = true
+
+	for _, f := range kubernetesEnvFields {
+		if value := os.Getenv(f.env); value != "" {
+			logger.fields = append(logger.fields, Field{f.key, value})
+		}
+	}
+
+	return logger
+}
@@ -0,0 +1,54 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// KV writes message of log level `logLevel` with key-value pairs `pairs` (`key1, value1, key2, value2...`),
+// e.g. configuration summary at startup. Terminal shows aligned `key: value` block with keys padded to common
+// width and dimmed; other outputs record pairs as fields. Missing value of last key is empty.
+func (l *Logger) KV(logLevel LogLevel, pairs ...any) (n int, err error) {
+	fields := make([]Field, 0, (len(pairs)+1)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		var value any = ""
+		if i+1 < len(pairs) {
+			value = pairs[i+1]
+		}
+
+		fields = append(fields, Field{fmt.Sprint(pairs[i]), value})
+	}
+
+	if !l.terminal() || len(fields) == 0 {
+		return l.log(logLevel, "", "", fields)
+	}
+
+	width := 0
+	for _, f := range fields {
+		width = max(width, lipgloss.Width(f.Key))
+	}
+
+	shown := fields
+	if l.MaxFieldBytes > 0 {
+		shown = bytesFields(fields, BytesHex, l.MaxFieldBytes)
+	}
+
+	lines := make([]string, len(fields))
+	for i, f := range shown {
+		key := sanitize(f.Key, l.Sanitize) + ":" + strings.Repeat(" ", width-lipgloss.Width(f.Key))
+		if l.colored() {
+			key = l.FieldStyle.Render(key)
+		}
+
+		value := sanitize(fmt.Sprint(fieldValue(f.Value)), l.Sanitize)
+		if style := l.valueStyle(f.Value); style != nil && l.colored() {
+			value = style.Render(value)
+		}
+
+		lines[i] = key + " " + value
+	}
+
+	return l.log(logLevel, "", strings.Join(lines, "\n"), fields)
+}
@@ -0,0 +1,77 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String returns name of log level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelTrace:
+		return "trace"
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	case LogLevelFatal:
+		return "fatal"
+	case LogLevelProgress:
+		return "progress"
+	}
+
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// ParseLevel returns log level by its name or symbol.
+func ParseLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace", "trc":
+		return LogLevelTrace, nil
+	case "debug", "dbg":
+		return LogLevelDebug, nil
+	case "info", "inf":
+		return LogLevelInfo, nil
+	case "warn", "warning", "wrn":
+		return LogLevelWarn, nil
+	case "error", "err":
+		return LogLevelError, nil
+	case "fatal", "ftl":
+		return LogLevelFatal, nil
+	case "progress":
+		return LogLevelProgress, nil
+	}
+
+	return 0, fmt.Errorf("unknown log level: %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = level
+
+	return nil
+}
+
+// Set implements flag.Value and pflag.Value.
+func (l *LogLevel) Set(s string) error {
+	return l.UnmarshalText([]byte(s))
+}
+
+// Type implements pflag.Value.
+func (l LogLevel) Type() string {
+	return "level"
+}
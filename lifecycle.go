@@ -0,0 +1,82 @@
+package simplelog
+
+import (
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// processStart is a time of program start used to compute uptime
+var processStart = time.Now()
+
+// bannerStyle is a style of application name and version in startup banner
+var bannerStyle = lipgloss.NewStyle().Bold(true)
+
+// Startup writes Info message `service started` with application name `appName`, version `version`, Go
+// version, PID, host and extra fields `extra` sorted by key. Terminal shows banner with application name and
+// version on the first line and the rest on the second one. Go version, PID and host are not written in
+// deterministic mode.
+func (l *Logger) Startup(appName, version string, extra Fields) (n int, err error) {
+	fields := []Field{
+		{"app", appName},
+		{"version", version}}
+
+	if !l.deterministic() {
+		host, _ := os.Hostname()
+		fields = append(fields, Field{"go", runtime.Version()}, Field{"pid", os.Getpid()}, Field{"host", host})
+	}
+
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fields = append(fields, Field{key, extra[key]})
+	}
+
+	if !l.terminal() {
+		return l.log(LogLevelInfo, "service started", "", fields)
+	}
+
+	title := sanitize(strings.TrimSpace(appName+" "+version), l.Sanitize)
+	details := make([]string, 0, len(fields)-2)
+	for _, f := range fields[2:] {
+		details = append(details, sanitize(f.Key+" "+formatValue(f.Value), l.Sanitize))
+	}
+	detail := strings.Join(details, " · ")
+
+	if l.colored() {
+		title = bannerStyle.Render(title)
+		detail = l.FieldStyle.Render(detail)
+	}
+
+	return l.log(LogLevelInfo, "service started", title+"\n"+detail, fields)
+}
+
+// ShutdownReason writes Info message `service stopped` with reason `reason`, uptime of program and number of
+// messages of each log level written by logger so far. It pairs with Startup. Uptime is zero in deterministic
+// mode.
+func (l *Logger) ShutdownReason(reason string) (n int, err error) {
+	uptime := time.Duration(0)
+	if !l.deterministic() {
+		uptime = l.now().Sub(processStart).Round(time.Second)
+	}
+
+	fields := []Field{
+		{"reason", reason},
+		{"uptime", uptime}}
+
+	counts := l.Counts()
+	for level := LogLevelTrace; level <= LogLevelFatal; level++ {
+		if count := counts[level]; count > 0 {
+			fields = append(fields, Field{level.String(), count})
+		}
+	}
+
+	return l.log(LogLevelInfo, "service stopped", "", fields)
+}
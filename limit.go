@@ -0,0 +1,182 @@
+package simplelog
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ErrEntryTooLarge is returned when message is dropped because it exceeds MaxEntryBytes
+var ErrEntryTooLarge = errors.New("log message is too large")
+
+// OversizePolicy defines how messages larger than MaxEntryBytes are handled
+type OversizePolicy int
+
+const (
+	// OversizeTruncate cuts longest field values and message text and appends TrimMarker
+	OversizeTruncate OversizePolicy = iota
+
+	// OversizeSplit writes message text as several messages with `part` field, e.g. `2/3`. Fields are
+	// repeated in every message and cut if they take more than half of limit
+	OversizeSplit
+
+	// OversizeDrop drops message with ErrEntryTooLarge
+	OversizeDrop
+)
+
+// limit applies MaxEntryBytes to entry `e` and returns entries to write. Size of entry is a size of message
+// text with fields in text format.
+func (l *Logger) limit(e *Entry) ([]*Entry, error) {
+	if l.MaxEntryBytes <= 0 || e.Level == LogLevelProgress {
+		return []*Entry{e}, nil
+	}
+
+	size := len(e.Message) + fieldsSize(e.Fields)
+	if size <= l.MaxEntryBytes {
+		if display := l.truncateDisplay(e.display); display != e.display {
+			// terminal representation of entry is cut regardless of policy
+			c := *e
+			c.display = display
+			return []*Entry{&c}, nil
+		}
+
+		return []*Entry{e}, nil
+	}
+
+	switch l.OversizePolicy {
+	case OversizeDrop:
+		l.mu.Lock()
+		l.summary.dropped++
+		l.mu.Unlock()
+
+		return nil, fmt.Errorf("%w: %d bytes", ErrEntryTooLarge, size)
+	case OversizeSplit:
+		// fields are repeated in every part, so they may take at most half of limit
+		fields := l.shrinkFields(e.Fields, l.MaxEntryBytes/2)
+		budget := l.MaxEntryBytes - fieldsSize(fields)
+		budget -= len(fmt.Sprintf(" part=%d/%d", len(e.Message)/max(budget, 1)+1, len(e.Message)/max(budget, 1)+1))
+
+		var parts []string
+		for s := e.Message; s != ""; {
+			i := cutIndex(s, max(budget, 1))
+			parts = append(parts, s[:i])
+			s = s[i:]
+		}
+		if len(parts) == 0 {
+			parts = []string{""}
+		}
+
+		entries := make([]*Entry, len(parts))
+		for i, part := range parts {
+			c := *e
+			c.Message = part
+			c.display, c.hidden = "", 0
+			c.Fields = append(fields[:len(fields):len(fields)], Field{"part", fmt.Sprintf("%d/%d", i+1, len(parts))})
+			entries[i] = &c
+		}
+
+		return entries, nil
+	}
+
+	// message keeps at least half of limit if it is needed
+	c := *e
+	c.Fields = l.shrinkFields(e.Fields, max(l.MaxEntryBytes-len(e.Message), l.MaxEntryBytes/2))
+	if n := l.MaxEntryBytes - fieldsSize(c.Fields); len(e.Message) > n {
+		c.Message = l.truncate(e.Message, n)
+	}
+	c.display = l.truncateDisplay(c.display)
+
+	return []*Entry{&c}, nil
+}
+
+// truncate cuts string `s` to `n` bytes including TrimMarker.
+func (l *Logger) truncate(s string, n int) string {
+	return s[:cutIndex(s, max(n-len(l.TrimMarker), 0))] + l.TrimMarker
+}
+
+// truncateDisplay cuts styled terminal text `s` to MaxEntryBytes cells of display width including TrimMarker.
+// Escape sequences are not split and kept after cut, so styles are reset.
+func (l *Logger) truncateDisplay(s string) string {
+	if ansi.StringWidth(s) <= l.MaxEntryBytes {
+		return s
+	}
+
+	return ansi.Truncate(s, l.MaxEntryBytes, l.TrimMarker)
+}
+
+// shrinkFields returns fields `fields` with longest values truncated, so size of fields does not exceed
+// `budget` bytes if possible. Original slice is not modified.
+func (l *Logger) shrinkFields(fields []Field, budget int) []Field {
+	size := fieldsSize(fields)
+	if size <= budget {
+		return fields
+	}
+
+	fields = slices.Clone(fields)
+	for size > budget {
+		i, longest := 0, -1
+		for j, f := range fields {
+			if n := len(formatValue(f.Value)); n > longest {
+				i, longest = j, n
+			}
+		}
+
+		s := valueText(fields[i].Value)
+		if len(s) <= len(l.TrimMarker) {
+			break
+		}
+		fields[i].Value = l.truncate(s, len(s)-(size-budget))
+
+		n := fieldsSize(fields)
+		if n >= size {
+			break
+		}
+		size = n
+	}
+
+	return fields
+}
+
+// fieldsSize returns size of fields `fields` appended to message in text format.
+func fieldsSize(fields []Field) int {
+	size := 0
+	for _, f := range fields {
+		size += len(" =") + len(f.Key) + len(formatValue(f.Value))
+	}
+
+	return size
+}
+
+// valueText returns unquoted string representation of field value.
+func valueText(v any) string {
+	switch v := v.(type) {
+	case rawValue:
+		return string(v)
+	case jsonText:
+		return string(v)
+	}
+
+	return fmt.Sprint(fieldValue(v))
+}
+
+// cutIndex returns index not greater than `n` where string `s` can be cut without splitting of UTF-8
+// sequence. At least one rune is kept if `n` is positive.
+func cutIndex(s string, n int) int {
+	if n >= len(s) {
+		return len(s)
+	}
+
+	i := n
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	if i == 0 && n > 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		i = size
+	}
+
+	return i
+}
@@ -0,0 +1,115 @@
+package simplelog
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLimit(t *testing.T) {
+	long := strings.Repeat("a", 100)
+
+	tests := []struct {
+		name    string
+		policy  OversizePolicy
+		message string
+		fields  []Field
+		entries int
+	}{
+		{"short", OversizeTruncate, "message", []Field{{"id", 7}}, 1},
+		{"truncate message", OversizeTruncate, long, []Field{{"id", 7}}, 1},
+		{"truncate field", OversizeTruncate, "message", []Field{{"body", long}}, 1},
+		{"truncate json", OversizeTruncate, "message", []Field{{"json", jsonText(`["` + long + `"]`)}}, 1},
+		{"truncate both", OversizeTruncate, long, []Field{{"body", long}, {"id", 7}}, 1},
+		{"split message", OversizeSplit, long, []Field{{"id", 7}}, 3},
+		{"split field", OversizeSplit, "message", []Field{{"body", long}}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := NewLogger(nil)
+			l.MaxEntryBytes = 50
+			l.OversizePolicy = test.policy
+
+			fields := append([]Field(nil), test.fields...)
+			entries, err := l.limit(&Entry{Level: LogLevelInfo, Message: test.message, Fields: fields})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != test.entries {
+				t.Fatalf("got %d entries, want %d", len(entries), test.entries)
+			}
+			for _, e := range entries {
+				if size := len(e.Message) + fieldsSize(e.Fields); size > l.MaxEntryBytes {
+					t.Errorf("entry %q %s takes %d bytes, want at most %d", e.Message, formatFields(e.Fields), size, l.MaxEntryBytes)
+				}
+			}
+			for i := range fields {
+				if fields[i] != test.fields[i] {
+					t.Errorf("field %s of original entry is modified", fields[i].Key)
+				}
+			}
+		})
+	}
+}
+
+func TestLimitDrop(t *testing.T) {
+	l := NewLogger(nil)
+	l.MaxEntryBytes = 50
+	l.OversizePolicy = OversizeDrop
+
+	entries, err := l.limit(&Entry{Level: LogLevelInfo, Message: "message", Fields: []Field{{"body", strings.Repeat("a", 100)}}})
+	if !errors.Is(err, ErrEntryTooLarge) || len(entries) != 0 {
+		t.Errorf("got %d entries with error %v, want %v", len(entries), err, ErrEntryTooLarge)
+	}
+}
+
+func TestLimitDisplay(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(buf)
+	l.Clock = func() time.Time { return time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local) }
+	l.MaxEntryBytes = 50
+
+	e := &Entry{Level: LogLevelInfo, Message: "label", display: "label\n" + strings.Repeat("a", 100)}
+	entries, err := l.limit(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entries[0].display; ansi.StringWidth(got) > l.MaxEntryBytes || !strings.HasSuffix(got, l.TrimMarker) {
+		t.Errorf("display is %q", got)
+	}
+
+	if _, err := l.JSON(LogLevelInfo, "label", []string{strings.Repeat("a", 100)}); err != nil {
+		t.Fatal(err)
+	}
+	if line := strings.TrimPrefix(strings.TrimSpace(buf.String()), "2000-01-01 00:00:00 |INF| "); len(line) > l.MaxEntryBytes {
+		t.Errorf("written entry %q takes %d bytes, want at most %d", line, len(line), l.MaxEntryBytes)
+	}
+}
+
+func TestLimitColoredDisplay(t *testing.T) {
+	l := NewLogger(nil)
+	l.MaxEntryBytes = 50
+
+	display := "label\n\x1b[31m" + strings.Repeat("é", 100) + "\x1b[0m"
+	entries, err := l.limit(&Entry{Level: LogLevelInfo, Message: "label", display: display})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := entries[0].display
+	if !utf8.ValidString(got) || !strings.HasSuffix(got, l.TrimMarker+"\x1b[0m") {
+		t.Errorf("display is %q", got)
+	}
+	if width := ansi.StringWidth(got); width > l.MaxEntryBytes {
+		t.Errorf("display width is %d, want at most %d", width, l.MaxEntryBytes)
+	}
+	if plain := ansi.Strip(got); strings.ContainsRune(plain, '\x1b') {
+		t.Errorf("display contains broken escape sequence: %q", got)
+	}
+}
@@ -0,0 +1,121 @@
+package simplelog
+
+import (
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Hyperlink is a field value or message part rendered as clickable OSC 8 hyperlink on terminals and as plain
+// text elsewhere
+type Hyperlink struct {
+	URL string
+
+	// Text of link, URL is shown if empty
+	Text string
+}
+
+// rawValue is a field value written as is without quoting
+type rawValue string
+
+// Link returns hyperlink to `url` with text `text`.
+func Link(url, text string) Hyperlink {
+	return Hyperlink{URL: url, Text: text}
+}
+
+// FileLink returns hyperlink to file `path` with `path:line` text. Line is omitted if it is not positive.
+func FileLink(path string, line int) Hyperlink {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+
+	text := path
+	if line > 0 {
+		text += ":" + strconv.Itoa(line)
+	}
+
+	return Hyperlink{
+		URL:  (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String(),
+		Text: text}
+}
+
+// String returns plain text representation of hyperlink: `text <url>` or URL if text is empty.
+func (h Hyperlink) String() string {
+	if h.Text == "" || h.Text == h.URL {
+		return h.URL
+	}
+
+	return h.Text + " <" + h.URL + ">"
+}
+
+// osc8 returns hyperlink as OSC 8 escape sequence.
+func (h Hyperlink) osc8() string {
+	text := h.Text
+	if text == "" {
+		text = h.URL
+	}
+
+	return "\x1b]8;;" + h.URL + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// Link returns hyperlink to `url` with text `text` for embedding into message: OSC 8 escape sequence if
+// logger writes to terminal or plain text otherwise.
+func (l *Logger) Link(url, text string) string {
+	h := Link(url, text)
+	if l.hyperlinks() {
+		return h.osc8()
+	}
+
+	return h.String()
+}
+
+// hyperlinks reports whether hyperlinks should be rendered as OSC 8 escape sequences.
+func (l *Logger) hyperlinks() bool {
+	return l.terminal() && !l.NoHyperlinks
+}
+
+// terminalFields returns fields with hyperlink values replaced by OSC 8 escape sequences.
+func terminalFields(fields []Field) []Field {
+	var replaced []Field
+	for i, f := range fields {
+		h, ok := f.Value.(Hyperlink)
+		if !ok {
+			continue
+		}
+
+		if replaced == nil {
+			replaced = append([]Field(nil), fields...)
+		}
+		replaced[i].Value = rawValue(h.osc8())
+	}
+
+	if replaced == nil {
+		return fields
+	}
+
+	return replaced
+}
+
+// callerLink returns URL of caller of entry `e` made from template CallerURL or `file://` URL if template is
+// empty.
+func (l *Logger) callerLink(e *Entry) string {
+	if l.CallerURL == "" {
+		return FileLink(e.Caller.File, 0).URL
+	}
+
+	return strings.NewReplacer("{path}", filepath.ToSlash(e.Caller.File), "{line}", strconv.Itoa(e.Caller.Line)).Replace(l.CallerURL)
+}
+
+// callerFields returns fields with caller field replaced by hyperlink to caller source.
+func (l *Logger) callerFields(e *Entry, fields []Field) []Field {
+	if !e.HasCaller() || len(fields) == 0 || fields[len(fields)-1].Key != "caller" {
+		return fields
+	}
+
+	fields = append([]Field(nil), fields...)
+	fields[len(fields)-1].Value = Link(l.callerLink(e), e.CallerString())
+
+	return fields
+}
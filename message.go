@@ -4,17 +4,27 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // msg represets fields of log message
 type msg struct {
 	TimeStamp string
 	Prefix    string
+	Tag       string
 	Text      string
+	Fields    string
+
+	// layout template, default layout is used if empty
+	Layout string
 }
 
 // String return string representation of message
 func (m *msg) String() string {
+	if m.Layout != "" {
+		return m.layout()
+	}
+
 	sb := new(strings.Builder)
 
 	if m.TimeStamp != "" {
@@ -27,25 +37,118 @@ func (m *msg) String() string {
 		sb.WriteRune(' ')
 	}
 
+	if m.Tag != "" {
+		sb.WriteString(m.Tag)
+		sb.WriteRune(' ')
+	}
+
 	sb.WriteString(m.Text)
 
+	if m.Fields != "" {
+		if m.Text != "" {
+			sb.WriteRune(' ')
+		}
+		sb.WriteString(m.Fields)
+	}
+
 	return sb.String()
 }
 
+// layout returns string representation of message made from layout template with `{time}`, `{level}`,
+// `{name}`, `{message}` and `{fields}` placeholders. Empty components are dropped with one following space.
+func (m *msg) layout() string {
+	components := map[string]string{
+		"time":    m.TimeStamp,
+		"level":   m.Prefix,
+		"name":    m.Tag,
+		"message": m.Text,
+		"fields":  m.Fields,
+	}
+
+	sb := new(strings.Builder)
+
+	tmpl := m.Layout
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		value, exists := components[tmpl[start+1:end]]
+		if !exists {
+			sb.WriteString(tmpl[:end+1])
+			tmpl = tmpl[end+1:]
+			continue
+		}
+
+		sb.WriteString(tmpl[:start])
+		sb.WriteString(value)
+		tmpl = tmpl[end+1:]
+
+		if value == "" {
+			tmpl = strings.TrimPrefix(tmpl, " ")
+		}
+	}
+
+	sb.WriteString(tmpl)
+
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// aligned returns string representation of message with fields starting at screen column `column` or
+// right-aligned to terminal width `width` if `right` is true. Fields are moved to continuation line indented
+// to the same column if they do not fit. Zero width means unknown terminal width.
+func (m *msg) aligned(column int, right bool, width int) string {
+	fields := m.Fields
+	m.Fields = ""
+	head := m.String()
+	m.Fields = fields
+
+	headWidth := lipgloss.Width(head)
+	fieldsWidth := lipgloss.Width(fields)
+
+	if right {
+		if width == 0 {
+			return head + " " + fields
+		}
+		column = width - fieldsWidth
+	}
+
+	if column > headWidth && (width == 0 || column+fieldsWidth <= width) {
+		return head + strings.Repeat(" ", column-headWidth) + fields
+	}
+
+	if width > 0 && column+fieldsWidth > width {
+		column = max(width-fieldsWidth, 0)
+	}
+
+	return head + "\n" + strings.Repeat(" ", column) + fields
+}
+
 // fit fits whole message to specified width `width` by reducing message text if needed. If message does not fit needed
 // width trim marker `trimMarker` will added to the end of message text.
 func (m *msg) fit(width int, trimMarker string) {
 	spaceCount := 0
 	if m.TimeStamp != "" {
-		spaceCount = 1
+		spaceCount++
+	}
+	if m.Tag != "" {
+		spaceCount++
+	}
+	if m.Fields != "" {
+		spaceCount++
 	}
 
-	spaceLeft := width - lipgloss.Width(m.TimeStamp) - lipgloss.Width(m.Prefix) - lipgloss.Width(m.Text) - spaceCount
+	spaceLeft := width - lipgloss.Width(m.TimeStamp) - lipgloss.Width(m.Prefix) - lipgloss.Width(m.Tag) - lipgloss.Width(m.Text) - lipgloss.Width(m.Fields) - spaceCount
 	if spaceLeft >= 0 {
 		return
 	}
 
-	maxMessageWidth := max(len([]rune(m.Text))+spaceLeft-len(trimMarker), 0)
-
-	m.Text = string([]rune(m.Text)[:maxMessageWidth]) + trimMarker
+	// text is cut by display width, so wide characters are accounted correctly
+	m.Text = ansi.Truncate(m.Text, max(lipgloss.Width(m.Text)+spaceLeft, 0), trimMarker)
 }
@@ -0,0 +1,37 @@
+package simplelog
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// processInfo returns host and process metadata fields. Values are collected once on first call.
+var processInfo = sync.OnceValue(func() []Field {
+	host, _ := os.Hostname()
+
+	exe, err := os.Executable()
+	if err == nil {
+		exe = filepath.Base(exe)
+	}
+
+	return []Field{
+		{"host", host},
+		{"pid", os.Getpid()},
+		{"exe", exe},
+	}
+})
+
+// metadataFields returns metadata fields which should be attached to message.
+func (l *Logger) metadataFields() []Field {
+	if !l.ProcessInfo {
+		return nil
+	}
+
+	fields := processInfo()
+	if l.AppVersion != "" {
+		fields = append(fields[:len(fields):len(fields)], Field{"version", l.AppVersion})
+	}
+
+	return fields
+}
@@ -0,0 +1,139 @@
+package simplelog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MQTTClient publishes messages to MQTT broker. Implementation is usually a thin adapter of MQTT client
+// library, so package does not depend on it.
+type MQTTClient interface {
+	// Publish publishes message and waits for its delivery according to QoS level `qos`.
+	Publish(ctx context.Context, topic string, qos byte, retained bool, payload []byte) error
+
+	// IsConnected reports whether client is connected to broker.
+	IsConnected() bool
+}
+
+// MQTTSink is a sink which publishes entries to MQTT topic. Entries are kept in offline buffer while client
+// is disconnected or publishing fails and are published after connection is restored.
+type MQTTSink struct {
+	*BatchSink
+
+	// Encoder of message payloads. It must not be changed after first entry is written.
+	Encoder Encoder
+
+	// QoS level of messages: 0, 1 or 2
+	QoS byte
+
+	// Retained enables retained messages
+	Retained bool
+
+	// OfflineBufferSize is a maximum number of entries kept while client is offline. Oldest entries are
+	// dropped when buffer is full.
+	OfflineBufferSize int
+
+	client MQTTClient
+	topic  string
+
+	// entries waiting for connection
+	offline []*Entry
+}
+
+// NewMQTTSink returns new sink which publishes entries as JSON messages to topic `topic` using client
+// `client`. Topic may contain `{level}` placeholder which is replaced by entry log level name.
+func NewMQTTSink(client MQTTClient, topic string, options BatchOptions) *MQTTSink {
+	s := &MQTTSink{
+		Encoder:           NewJSONEncoder(),
+		QoS:               1,
+		OfflineBufferSize: defaultMQTTOfflineBufferSize,
+		client:            client,
+		topic:             topic}
+
+	// offline buffering replaces retries, offline entries are retried on each flush interval
+	options.MaxRetries = -1
+	s.BatchSink = newBatchSink(s.flush, options, true)
+
+	return s
+}
+
+// Close flushes queued entries and stops background goroutine. Entries left in offline buffer are dropped
+// and reported by returned error.
+func (s *MQTTSink) Close() error {
+	s.BatchSink.Close()
+
+	// flushes are stopped, so offline buffer is not used concurrently
+	offline := s.offline
+	s.offline = nil
+	if len(offline) == 0 {
+		return nil
+	}
+
+	err := fmt.Errorf("MQTT client is offline: %d buffered messages dropped", len(offline))
+	s.dropped.Add(int64(len(offline)))
+
+	s.mu.RLock()
+	u := s.undelivered
+	s.mu.RUnlock()
+
+	if u != nil {
+		u(offline, err)
+	}
+
+	return err
+}
+
+// flush publishes offline entries and batch; entries that can not be published are kept in offline buffer.
+func (s *MQTTSink) flush(ctx context.Context, batch []*Entry) error {
+	if len(s.offline) == 0 && len(batch) == 0 {
+		return nil
+	}
+
+	pending := append(s.offline, batch...)
+	s.offline = nil
+
+	for i, e := range pending {
+		if !s.client.IsConnected() {
+			return s.keepOffline(pending[i:], nil)
+		}
+
+		payload, err := s.Encoder.Encode(e)
+		if err != nil {
+			return err
+		}
+
+		topic := strings.ReplaceAll(s.topic, "{level}", e.Level.String())
+		if err := s.client.Publish(ctx, topic, s.QoS, s.Retained, bytes.TrimSuffix(payload, []byte{'\n'})); err != nil {
+			return s.keepOffline(pending[i:], err)
+		}
+	}
+
+	return nil
+}
+
+// keepOffline keeps entries in offline buffer dropping oldest ones over limit. Returned error reports
+// publish error `err` and dropped entries.
+func (s *MQTTSink) keepOffline(entries []*Entry, err error) error {
+	s.offline = append(s.offline[:0], entries...)
+
+	dropped := 0
+	if over := len(s.offline) - s.OfflineBufferSize; over > 0 {
+		dropped = over
+		s.offline = append(s.offline[:0], s.offline[over:]...)
+	}
+
+	switch {
+	case dropped > 0 && err != nil:
+		err = fmt.Errorf("publish log message: %w; %d buffered messages dropped", err, dropped)
+	case dropped > 0:
+		err = fmt.Errorf("MQTT client is offline: %d buffered messages dropped", dropped)
+	case err != nil:
+		err = fmt.Errorf("publish log message: %w", err)
+	default:
+		return nil
+	}
+
+	return &droppedError{n: dropped, err: err}
+}
@@ -0,0 +1,116 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mqttClient is a client which publishes messages while it is connected
+type mqttClient struct {
+	mu        sync.Mutex
+	connected bool
+	topics    []string
+	payloads  []string
+}
+
+func (c *mqttClient) Publish(ctx context.Context, topic string, qos byte, retained bool, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected {
+		return errors.New("not connected")
+	}
+	c.topics = append(c.topics, topic)
+	c.payloads = append(c.payloads, string(payload))
+
+	return nil
+}
+
+func (c *mqttClient) IsConnected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.connected
+}
+
+func (c *mqttClient) setConnected(connected bool) {
+	c.mu.Lock()
+	c.connected = connected
+	c.mu.Unlock()
+}
+
+func TestMQTTOfflineBuffer(t *testing.T) {
+	client := new(mqttClient)
+	s := NewMQTTSink(client, "logs/{level}", BatchOptions{Size: 1, Interval: 10 * time.Millisecond})
+
+	for _, message := range []string{"a", "b"} {
+		if err := s.WriteEntry(&Entry{Time: time.Now(), Level: LogLevelInfo, Message: message}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	client.setConnected(true)
+	time.Sleep(50 * time.Millisecond)
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.payloads) != 2 || !strings.Contains(client.payloads[0], `"msg":"a"`) || !strings.Contains(client.payloads[1], `"msg":"b"`) {
+		t.Errorf("published %q", client.payloads)
+	}
+	if len(client.topics) > 0 && client.topics[0] != "logs/info" {
+		t.Errorf("published to topic %q, want %q", client.topics[0], "logs/info")
+	}
+	if got := s.Dropped(); got != 0 {
+		t.Errorf("dropped %d entries, want 0", got)
+	}
+}
+
+func TestMQTTOfflineBufferLimit(t *testing.T) {
+	client := new(mqttClient)
+	s := NewMQTTSink(client, "logs", BatchOptions{Size: 1, Interval: time.Hour})
+	s.OfflineBufferSize = 2
+
+	var errs []error
+	s.SetErrorHandler(func(err error) { errs = append(errs, err) })
+
+	for _, message := range []string{"a", "b", "c"} {
+		if err := s.WriteEntry(&Entry{Time: time.Now(), Level: LogLevelInfo, Message: message}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Close(); err == nil {
+		t.Error("offline entries are dropped on close without error")
+	}
+	if got := s.Dropped(); got != 3 {
+		t.Errorf("dropped %d entries, want 3", got)
+	}
+	if len(errs) != 1 {
+		t.Errorf("got %d errors of full offline buffer, want 1", len(errs))
+	}
+}
+
+func TestMQTTCloseOffline(t *testing.T) {
+	client := new(mqttClient)
+	s := NewMQTTSink(client, "logs", BatchOptions{Interval: time.Hour})
+
+	if err := s.WriteEntry(&Entry{Time: time.Now(), Level: LogLevelInfo, Message: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLogger(nil)
+	l.AddSink(s)
+	if err := l.Shutdown(context.Background()); err == nil {
+		t.Error("shutdown reports no error after offline entries are dropped")
+	}
+	if got := s.Dropped(); got != 1 {
+		t.Errorf("dropped %d entries, want 1", got)
+	}
+}
@@ -0,0 +1,56 @@
+package simplelog
+
+import (
+	"io"
+	"sync"
+)
+
+// Mux interleaves messages of many named sources into single output like docker-compose does: each line
+// is prefixed with left-aligned tag column colored with stable per-source color.
+type Mux struct {
+	Writer io.Writer
+
+	// tag column shared by all sources
+	tags *tagColumn
+
+	// state of progress line shared by all sources
+	progress *progressState
+
+	// statistics of messages shared by all sources
+	summary *summaryState
+
+	// mutex shared by all sources
+	mu *sync.Mutex
+}
+
+// NewMux returns new mux which writes messages of all sources to `w`.
+func NewMux(w io.Writer) *Mux {
+	m := &Mux{
+		Writer:   w,
+		tags:     newTagColumn(),
+		progress: new(progressState),
+		summary:  newSummaryState(),
+		mu:       new(sync.Mutex)}
+
+	return m
+}
+
+// Logger returns logger for source `name`. All messages of returned logger are tagged with source name.
+func (m *Mux) Logger(name string) *Logger {
+	m.tags.register(name)
+
+	l := NewLogger(m.Writer)
+	l.name = name
+	l.tags = m.tags
+	l.progress = m.progress
+	l.summary = m.summary
+	l.mu = m.mu
+
+	return l
+}
+
+// Source returns writer for source `name` which writes every line as separate Info message. It is useful
+// for interleaving output of child processes. Close must be called to flush last unterminated line.
+func (m *Mux) Source(name string) io.WriteCloser {
+	return newLineWriter(m.Logger(name), LogLevelInfo)
+}
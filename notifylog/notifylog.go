@@ -0,0 +1,116 @@
+// Package notifylog provides simplelog sink which shows desktop notifications for high severity messages,
+// e.g. for long-running interactive jobs the user walks away from.
+package notifylog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/nxshock/simplelog"
+)
+
+// Notifier shows desktop notification
+type Notifier interface {
+	Notify(title, body string) error
+}
+
+// OSC777 is a notifier which writes OSC 777 escape sequence supported by some terminals like urxvt, foot
+// and WezTerm to terminal `Writer`
+type OSC777 struct {
+	Writer io.Writer
+}
+
+// Notify implements Notifier.
+func (n OSC777) Notify(title, body string) error {
+	_, err := fmt.Fprintf(n.Writer, "\x1b]777;notify;%s;%s\x1b\\", oscText(title), oscText(body))
+
+	return err
+}
+
+// oscText removes characters which break OSC 777 sequence.
+func oscText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ';' || r < ' ' || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// Desktop is a notifier which runs platform notification tool: `notify-send` on Linux and BSD, `osascript`
+// on macOS and PowerShell on Windows
+type Desktop struct{}
+
+// Notify implements Notifier.
+func (Desktop) Notify(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e", fmt.Sprintf("display notification %s with title %s",
+			appleScriptString(body), appleScriptString(title)))
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-Command",
+			"[reflection.assembly]::loadwithpartialname('System.Windows.Forms') | Out-Null;"+
+				"$n = New-Object System.Windows.Forms.NotifyIcon;"+
+				"$n.Icon = [System.Drawing.SystemIcons]::Error; $n.Visible = $true;"+
+				"$n.ShowBalloonTip(10000, $env:NOTIFY_TITLE, $env:NOTIFY_BODY, 'Error'); Start-Sleep 1")
+		cmd.Env = append(os.Environ(), "NOTIFY_TITLE="+title, "NOTIFY_BODY="+body)
+	default:
+		cmd = exec.Command("notify-send", "--urgency=critical", "--", title, body)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("show notification: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// appleScriptString returns AppleScript string literal.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Sink is a simplelog sink which shows notification for each message of Level or higher. Notification is
+// shown synchronously, so it is shown before Fatal methods terminate program.
+type Sink struct {
+	// Minimum log level of notified messages, default is Fatal
+	Level simplelog.LogLevel
+
+	// Title of notifications, default is executable name. Message source is appended to title.
+	Title string
+
+	notifier Notifier
+}
+
+// NewSink returns new sink which shows notifications of Fatal messages using notifier `n`.
+func NewSink(n Notifier) *Sink {
+	title := "simplelog"
+	if exe, err := os.Executable(); err == nil {
+		title = filepath.Base(exe)
+	}
+
+	return &Sink{
+		Level:    simplelog.LogLevelFatal,
+		Title:    title,
+		notifier: n}
+}
+
+// WriteEntry implements simplelog.Sink.
+func (s *Sink) WriteEntry(e *simplelog.Entry) error {
+	if e.Level < s.Level || e.Level == simplelog.LogLevelProgress {
+		return nil
+	}
+
+	title := s.Title
+	if e.Source != "" {
+		title += ": " + e.Source
+	}
+
+	return s.notifier.Notify(title, e.Message)
+}
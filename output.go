@@ -0,0 +1,201 @@
+package simplelog
+
+import (
+	"fmt"
+	"io"
+)
+
+// Output is an additional destination of log messages: writer with its own encoder or sink which receives
+// entries as is
+type Output struct {
+	Encoder Encoder
+	Writer  io.Writer
+
+	Sink Sink
+
+	// Level is a minimum log level of entries written to output. It is applied independently of logger
+	// level: output receives entries of lower levels than logger level too. Logger level is used if it is nil.
+	Level *LogLevel
+
+	// write statistics
+	stats OutputStats
+}
+
+// SetLevel sets minimum log level of entries written to output.
+func (o *Output) SetLevel(level LogLevel) *Output {
+	o.Level = &level
+
+	return o
+}
+
+// enabled reports whether entries of level `logLevel` are written to output when logger level is `minLevel`.
+func (o *Output) enabled(logLevel, minLevel LogLevel) bool {
+	if o.Level != nil {
+		return logLevel >= *o.Level
+	}
+
+	return logLevel >= minLevel
+}
+
+// Sink receives entries directly without encoding, e.g. to send them to remote service
+type Sink interface {
+	// WriteEntry writes entry `e`. Sink may retain entry after return.
+	WriteEntry(e *Entry) error
+}
+
+// AddOutput adds output which writes all messages except progress ones to `w` encoded by `enc`.
+func (l *Logger) AddOutput(enc Encoder, w io.Writer) *Output {
+	l.setErrorHandlerOf(w)
+
+	output := &Output{Encoder: enc, Writer: w}
+	l.Outputs = append(l.Outputs, output)
+
+	return output
+}
+
+// AddSink adds output which writes all messages except progress ones to sink `s`. If sink has
+// SetErrorHandler(func(error)) method, it is called with handler which passes errors to logger ErrorHandler,
+// so asynchronous sinks can report delivery errors. The same is done for writers of AddOutput and main
+// writer.
+func (l *Logger) AddSink(s Sink) *Output {
+	l.setErrorHandlerOf(s)
+
+	output := &Output{Sink: s}
+	l.Outputs = append(l.Outputs, output)
+
+	return output
+}
+
+// encoded represents entry encoded for additional output
+type encoded struct {
+	b    []byte
+	err  error
+	done bool
+}
+
+// encodeOutputs encodes entry for additional outputs when logger level is `minLevel`. Sinks and encoders which
+// depend on order of entries are skipped, they are called by writeOutputs.
+func (l *Logger) encodeOutputs(e *Entry, minLevel LogLevel) []encoded {
+	if e.Level == LogLevelProgress || len(l.Outputs) == 0 {
+		return nil
+	}
+
+	result := make([]encoded, len(l.Outputs))
+	for i, output := range l.Outputs {
+		if !output.enabled(e.Level, minLevel) || output.Sink != nil || isOrdered(output.Encoder) {
+			continue
+		}
+
+		b, err := output.Encoder.Encode(e)
+		result[i] = encoded{b: b, err: err, done: true}
+	}
+
+	return result
+}
+
+// writeOutputs writes entry to additional outputs using entry encoded by encodeOutputs when logger level is
+// `minLevel`. Must be called with locked mutex.
+func (l *Logger) writeOutputs(e *Entry, encoded []encoded, minLevel LogLevel) {
+	if e.Level == LogLevelProgress {
+		return
+	}
+
+	for i, output := range l.Outputs {
+		if !output.enabled(e.Level, minLevel) {
+			continue
+		}
+
+		if output.Sink != nil {
+			if err := output.Sink.WriteEntry(e); err != nil {
+				l.summary.dropped++
+				l.handleError(fmt.Errorf("write log message: %w", err))
+				continue
+			}
+			output.stats.record(0, l.now())
+			continue
+		}
+
+		var b []byte
+		var err error
+		if i < len(encoded) && encoded[i].done {
+			b, err = encoded[i].b, encoded[i].err
+		} else {
+			b, err = output.Encoder.Encode(e)
+		}
+		if err != nil {
+			l.summary.dropped++
+			l.handleError(fmt.Errorf("encode log message: %w", err))
+			continue
+		}
+
+		n, err := output.Writer.Write(b)
+		if err != nil {
+			rollback(output.Encoder)
+			l.summary.dropped++
+			l.handleError(fmt.Errorf("write log message: %w", err))
+			continue
+		}
+		output.stats.record(n, l.now())
+	}
+}
+
+// orderedEncoder is implemented by encoders whose output depends on order of encoded entries, they are
+// called with locked mutex in order of writing
+type orderedEncoder interface {
+	ordered()
+}
+
+// isOrdered reports whether encoder `enc` depends on order of encoded entries.
+func isOrdered(enc Encoder) bool {
+	_, ok := enc.(orderedEncoder)
+	return ok
+}
+
+// rollbackEncoder is implemented by ordered encoders which revert state changed by last Encode call when
+// encoded entry is not written
+type rollbackEncoder interface {
+	rollback()
+}
+
+// rollback reverts state of encoder `enc` changed by last Encode call if it is rollbackEncoder.
+func rollback(enc Encoder) {
+	if r, ok := enc.(rollbackEncoder); ok {
+		r.rollback()
+	}
+}
+
+// setErrorHandlerOf calls SetErrorHandler(func(error)) method of `v` if it exists with handler which passes
+// errors to logger ErrorHandler.
+func (l *Logger) setErrorHandlerOf(v any) {
+	setErrorHandler(v, l.handleError)
+}
+
+// setErrorHandler calls SetErrorHandler(func(error)) method of `v` with handler `h` if it exists.
+func setErrorHandler(v any, h func(error)) {
+	if r, ok := v.(interface{ SetErrorHandler(func(error)) }); ok {
+		r.SetErrorHandler(h)
+	}
+}
+
+// write writes entry `e` to output without pre-encoding.
+func (o *Output) write(e *Entry) error {
+	if o.Sink != nil {
+		return o.Sink.WriteEntry(e)
+	}
+
+	b, err := o.Encoder.Encode(e)
+	if err != nil {
+		return fmt.Errorf("encode log message: %w", err)
+	}
+
+	_, err = o.Writer.Write(b)
+
+	return err
+}
+
+// handleError passes error of output to ErrorHandler.
+func (l *Logger) handleError(err error) {
+	if l.ErrorHandler != nil {
+		l.ErrorHandler(err)
+	}
+}
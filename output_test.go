@@ -0,0 +1,47 @@
+package simplelog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOutputLevels(t *testing.T) {
+	main, debug, warn, inherited := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+
+	l := NewLogger(main)
+	l.SetLevel(LogLevelInfo)
+	l.AddOutput(&TextEncoder{}, debug).SetLevel(LogLevelDebug)
+	l.AddOutput(&TextEncoder{}, warn).SetLevel(LogLevelWarn)
+	l.AddOutput(&TextEncoder{}, inherited)
+
+	l.Trace("trace")
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+
+	tests := []struct {
+		name string
+		buf  *bytes.Buffer
+		want []string
+	}{
+		{"main", main, []string{"info", "warn"}},
+		{"debug", debug, []string{"debug", "info", "warn"}},
+		{"warn", warn, []string{"warn"}},
+		{"inherited", inherited, []string{"info", "warn"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, line := range strings.Split(strings.TrimSpace(test.buf.String()), "\n") {
+				fields := strings.Fields(line)
+				got = append(got, fields[len(fields)-1])
+			}
+
+			if strings.Join(got, " ") != strings.Join(test.want, " ") {
+				t.Errorf("got messages %q, want %q", got, test.want)
+			}
+		})
+	}
+}
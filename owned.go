@@ -0,0 +1,61 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NewLoggerOwned returns new logger which writes messages to `wc` and takes ownership of it: Close closes
+// `wc` after flushing of logger.
+func NewLoggerOwned(wc io.WriteCloser) *Logger {
+	l := NewLogger(wc)
+	l.owned = wc
+
+	return l
+}
+
+// Close clears progress line and shuts down logger like Shutdown does. Writer is closed too if logger owns
+// it (see NewLoggerOwned). Messages written after Close are dropped with ErrLoggerShutdown.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	l.clearProgress()
+	l.mu.Unlock()
+
+	err := l.Shutdown(context.Background())
+
+	if l.owned != nil {
+		if cerr := l.owned.Close(); cerr != nil {
+			err = errors.Join(err, fmt.Errorf("close log output: %w", cerr))
+		}
+	}
+
+	return err
+}
+
+// clearProgress erases active progress line. Must be called with locked mutex.
+func (l *Logger) clearProgress() {
+	if l.terminal() && l.progress.lineWidth > 0 {
+		if termWidth := l.getWidth(); termWidth > 0 && l.progress.lineWidth > termWidth {
+			l.writer().Write([]byte(eraseRows(l.progress.lineWidth, termWidth)))
+		} else {
+			l.writer().Write([]byte("\r" + strings.Repeat(" ", l.progress.lineWidth) + "\r"))
+		}
+	}
+	l.progress.lineWidth = 0
+}
+
+// outputClosed handles writer closed while logger is running: progress state is reset and error is passed to
+// ErrorHandler once, so closed writer does not flood it. Must be called with locked mutex.
+func (l *Logger) outputClosed(err error) {
+	l.progress.lineWidth = 0
+
+	if l.summary.outputClosed {
+		return
+	}
+
+	l.summary.outputClosed = true
+	l.handleError(fmt.Errorf("write log message: %w", err))
+}
@@ -0,0 +1,434 @@
+package simplelog
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errNotEntry is returned by line parsers for lines which do not start new entry
+var errNotEntry = errors.New("line does not start log entry")
+
+// Scanner reads entries written in plain text or JSON format of this package. Lines of audit logs are
+// accepted too, their hashes are not verified. Lines which do not start new entry are treated as
+// continuation of previous multi-line message. Trailing `key=value` tokens of plain text messages are read as
+// fields. Message text which would be read ambiguously is written quoted, so it is read back as is.
+type Scanner struct {
+	// TimeFormat is a timestamp format of plain text lines. Empty value means lines without timestamps.
+	TimeFormat string
+
+	lines   *bufio.Scanner
+	lineNum int
+
+	entry   *Entry
+	pending *Entry
+	err     error
+
+	// is pending entry read from plain text line, its fields are extracted after all lines are read
+	pendingText bool
+}
+
+// NewScanner returns new scanner which reads entries from `r`. Default file timestamp format is expected.
+func NewScanner(r io.Reader) *Scanner {
+	lines := bufio.NewScanner(r)
+	lines.Buffer(nil, maxAuditLineSize)
+
+	return &Scanner{
+		TimeFormat: defaultFileTimestampFormat,
+		lines:      lines}
+}
+
+// Scan advances scanner to next entry. It returns false at the end of input or on error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for s.lines.Scan() {
+		s.lineNum++
+
+		line := s.lines.Text()
+		if strings.TrimSpace(line) == "" && s.pending == nil {
+			continue
+		}
+
+		entry, isText, err := s.parseLine(line)
+		if errors.Is(err, errNotEntry) {
+			if s.pending == nil {
+				s.err = fmt.Errorf("line %d: %w", s.lineNum, err)
+				return false
+			}
+			s.pending.Message += "\n" + line
+			continue
+		}
+		if err != nil {
+			s.err = fmt.Errorf("line %d: %w", s.lineNum, err)
+			return false
+		}
+
+		if s.pending != nil {
+			s.entry = s.finish()
+			s.pending, s.pendingText = entry, isText
+			return true
+		}
+		s.pending, s.pendingText = entry, isText
+	}
+
+	if err := s.lines.Err(); err != nil {
+		s.err = err
+		return false
+	}
+
+	if s.pending != nil {
+		s.entry = s.finish()
+		s.pending = nil
+		return true
+	}
+
+	return false
+}
+
+// finish returns pending entry with extracted fields.
+func (s *Scanner) finish() *Entry {
+	entry := s.pending
+
+	if s.pendingText {
+		entry.Message, entry.Fields = splitTextFields(entry.Message)
+		extractCaller(entry)
+	}
+
+	return entry
+}
+
+// Entry returns entry read by last Scan call.
+func (s *Scanner) Entry() *Entry {
+	return s.entry
+}
+
+// Err returns first error occurred during scanning.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// Parse reads all entries written in plain text or JSON format of this package from `r`.
+func Parse(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	s := NewScanner(r)
+	for s.Scan() {
+		entries = append(entries, *s.Entry())
+	}
+
+	return entries, s.Err()
+}
+
+// parseLine parses single line which starts new entry and reports whether it is plain text line which fields
+// are left in message text.
+func (s *Scanner) parseLine(line string) (entry *Entry, isText bool, err error) {
+	line = stripAuditHash(line)
+
+	if strings.HasPrefix(line, "{") {
+		entry, err = parseJSONLine(line, s.TimeFormat)
+		if err != nil && s.pending != nil {
+			// line of multi-line message which looks like JSON, e.g. indented JSON body
+			return nil, false, errNotEntry
+		}
+		return entry, false, err
+	}
+
+	entry, err = parseTextLine(line, s.TimeFormat)
+	if err != nil {
+		return nil, true, err
+	}
+
+	// fields of quoted message are known at once, they are not extracted from continuation lines
+	if text, fields, ok := unquoteText(entry.Message); ok {
+		entry.Message, entry.Fields = text, fields
+		extractCaller(entry)
+
+		return entry, false, nil
+	}
+
+	return entry, true, nil
+}
+
+// stripAuditHash removes hash prefix of audit log line if it exists.
+func stripAuditHash(line string) string {
+	hashLen := hex.EncodedLen(sha256.Size)
+
+	if len(line) <= hashLen || line[hashLen] != ' ' {
+		return line
+	}
+
+	if _, err := hex.DecodeString(line[:hashLen]); err != nil {
+		return line
+	}
+
+	return line[hashLen+1:]
+}
+
+// parseTextLine parses line of plain text format. Fields are left in message text.
+func parseTextLine(line, timeFormat string) (*Entry, error) {
+	i := levelPrefixIndex(line)
+	if i < 0 {
+		return nil, errNotEntry
+	}
+
+	entry := new(Entry)
+
+	level, err := ParseLevel(line[i+1 : i+4])
+	if err != nil {
+		return nil, err
+	}
+	entry.Level = level
+
+	if timeFormat != "" {
+		t, err := time.ParseInLocation(timeFormat, strings.TrimSpace(line[:i]), time.Local)
+		if err != nil {
+			return nil, errNotEntry
+		}
+		entry.Time = t
+	}
+
+	rest := strings.TrimPrefix(line[i+5:], " ")
+
+	// source tag column: `name<padding> | `, quoted message text is never a tag
+	if name, text, ok := strings.Cut(rest, " | "); ok && isTagName(name) {
+		entry.Source = strings.TrimRight(name, " ")
+		rest = text
+	} else if name, ok := strings.CutSuffix(rest, " |"); ok && isTagName(name) {
+		entry.Source = strings.TrimRight(name, " ")
+		rest = ""
+	}
+
+	entry.Message = rest
+
+	return entry, nil
+}
+
+// isTagName reports whether `s` may be padded name of source tag column.
+func isTagName(s string) bool {
+	name := strings.TrimRight(s, " ")
+
+	return name != "" && !strings.HasPrefix(name, `"`) && !strings.ContainsAny(name, " \t")
+}
+
+// unquoteText splits message text quoted by quoteText and its trailing fields. It returns false if text is
+// not quoted.
+func unquoteText(s string) (string, []Field, bool) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", nil, false
+	}
+
+	quoted, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", nil, false
+	}
+	text, err := strconv.Unquote(quoted)
+	if err != nil {
+		return "", nil, false
+	}
+
+	rest := s[len(quoted):]
+	if rest == "" {
+		return text, nil, true
+	}
+	if !strings.HasPrefix(rest, " ") {
+		return "", nil, false
+	}
+
+	// anything except fields after quoted text means that message just starts with quote
+	unparsed, fields := splitTextFields(rest[1:])
+	if unparsed != "" {
+		return "", nil, false
+	}
+
+	return text, fields, true
+}
+
+// quoteText returns message text `s` of plain text line quoted if Scanner would not read it back as is: text
+// starts with quote, looks like source tag column or ends with `key=value`-like token.
+func quoteText(s string) string {
+	if _, _, isField := lastTextField(s); isField || strings.HasPrefix(s, `"`) || strings.Contains(s, " | ") || strings.HasSuffix(s, " |") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+// levelPrefixIndex returns index of `|INF|`-like level prefix or -1 if line has no prefix.
+func levelPrefixIndex(line string) int {
+	for i := 0; i+5 <= len(line); i++ {
+		if line[i] != '|' || line[i+4] != '|' {
+			continue
+		}
+
+		if (i == 0 || line[i-1] == ' ') && (i+5 == len(line) || line[i+5] == ' ') {
+			if _, err := ParseLevel(line[i+1 : i+4]); err == nil {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// splitTextFields splits text into message and trailing `key=value` fields.
+func splitTextFields(s string) (string, []Field) {
+	var fields []Field
+
+	for {
+		i, f, ok := lastTextField(s)
+		if !ok {
+			break
+		}
+
+		fields = append([]Field{f}, fields...)
+		s = s[:i]
+		if s == "" {
+			break
+		}
+		s = s[:len(s)-1]
+	}
+
+	return s, fields
+}
+
+// lastTextField returns last `key=value` field of text and its start index.
+func lastTextField(s string) (int, Field, bool) {
+	value := ""
+	valueStart := 0
+
+	if strings.HasSuffix(s, `"`) {
+		// quoted value: find opening quote which makes valid Go string
+		for i := len(s) - 2; i >= 0; i-- {
+			if s[i] != '"' || i == 0 || s[i-1] != '=' {
+				continue
+			}
+
+			unquoted, err := strconv.Unquote(s[i:])
+			if err != nil {
+				continue
+			}
+			value, valueStart = unquoted, i
+			break
+		}
+		if valueStart == 0 {
+			return 0, Field{}, false
+		}
+	} else {
+		i := strings.LastIndexAny(s, " =")
+		if i < 0 || s[i] != '=' {
+			return 0, Field{}, false
+		}
+		value, valueStart = s[i+1:], i+1
+	}
+
+	keyEnd := valueStart - 1
+	keyStart := strings.LastIndexByte(s[:keyEnd], ' ') + 1
+	key := s[keyStart:keyEnd]
+	if key == "" || strings.ContainsAny(key, "=\"") {
+		return 0, Field{}, false
+	}
+
+	return keyStart, Field{key, value}, true
+}
+
+// parseJSONLine parses line of JSON format.
+func parseJSONLine(line, timeFormat string) (*Entry, error) {
+	dec := json.NewDecoder(strings.NewReader(line))
+
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, errNotEntry
+	}
+
+	entry := new(Entry)
+	hasLevel := false
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("parse JSON entry: %w", err)
+		}
+		key, _ := t.(string)
+
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("parse JSON entry: %w", err)
+		}
+		s, _ := value.(string)
+
+		switch key {
+		case "time":
+			t, err := parseTime(s, timeFormat)
+			if err != nil {
+				return nil, fmt.Errorf("parse JSON entry time: %w", err)
+			}
+			entry.Time = t
+		case "level":
+			level, err := ParseLevel(s)
+			if err != nil {
+				return nil, err
+			}
+			entry.Level = level
+			hasLevel = true
+		case "source":
+			entry.Source = s
+		case "msg":
+			entry.Message = s
+		default:
+			entry.Fields = append(entry.Fields, Field{key, value})
+		}
+	}
+
+	if !hasLevel {
+		return nil, errors.New("JSON entry has no level")
+	}
+
+	extractCaller(entry)
+
+	return entry, nil
+}
+
+// parseTime parses timestamp in RFC 3339 or `timeFormat` format.
+func parseTime(s, timeFormat string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err == nil || timeFormat == "" {
+		return t, err
+	}
+
+	return time.ParseInLocation(timeFormat, s, time.Local)
+}
+
+// extractCaller moves `caller` field of entry to its Caller.
+func extractCaller(entry *Entry) {
+	for j, f := range entry.Fields {
+		s, ok := f.Value.(string)
+		if f.Key != "caller" || !ok {
+			continue
+		}
+
+		i := strings.LastIndexByte(s, ':')
+		if i < 0 {
+			continue
+		}
+
+		file := s[:i]
+		n, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			continue
+		}
+
+		entry.Caller.File, entry.Caller.Line = file, n
+		entry.Fields = append(entry.Fields[:j:j], entry.Fields[j+1:]...)
+
+		return
+	}
+}
@@ -0,0 +1,116 @@
+package simplelog
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		message string
+		fields  []Field
+	}{
+		{"plain", "", "started", nil},
+		{"fields", "", "started", []Field{{"user", "bob"}, {"id", "7"}}},
+		{"separator", "", "foo | bar", nil},
+		{"trailing separator", "", "foo |", nil},
+		{"separator with fields", "", "foo | bar", []Field{{"id", "7"}}},
+		{"key value", "", "set x=5", nil},
+		{"key value with fields", "", "set x=5", []Field{{"y", "1"}}},
+		{"quote", "", `"quoted" text`, nil},
+		{"quote with fields", "", `"a=1"`, []Field{{"b", "2"}}},
+		{"empty", "", "", []Field{{"id", "7"}}},
+		{"tagged", "api", "started", []Field{{"id", "7"}}},
+		{"tagged separator", "api", "foo | bar", nil},
+		{"tagged key value", "api", "set x=5", nil},
+	}
+
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			l := NewLogger(buf)
+			if test.source != "" {
+				l = NewMux(buf).Logger(test.source)
+			}
+			l.Clock = func() time.Time { return now }
+			if len(test.fields) > 0 {
+				l = l.With(test.fields...)
+			}
+
+			if _, err := l.Info(test.message); err != nil {
+				t.Fatal(err)
+			}
+
+			entries, err := Parse(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("parsed %d entries from %q, want 1", len(entries), buf.String())
+			}
+
+			e := entries[0]
+			if e.Source != test.source || e.Message != test.message || !e.Time.Equal(now) || e.Level != LogLevelInfo {
+				t.Errorf("parsed source %q, message %q, time %s, level %v", e.Source, e.Message, e.Time, e.Level)
+			}
+			if len(e.Fields) > 0 || len(test.fields) > 0 {
+				if !reflect.DeepEqual(e.Fields, test.fields) {
+					t.Errorf("parsed fields %v, want %v", e.Fields, test.fields)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMultiline(t *testing.T) {
+	input := "2000-01-01 00:00:00 |ERR| first\nsecond id=7\n2000-01-01 00:00:01 |INF| next\n"
+
+	entries, err := Parse(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("parsed %d entries, want 2", len(entries))
+	}
+	if entries[0].Message != "first\nsecond" || !reflect.DeepEqual(entries[0].Fields, []Field{{"id", "7"}}) {
+		t.Errorf("parsed message %q with fields %v", entries[0].Message, entries[0].Fields)
+	}
+	if entries[1].Message != "next" {
+		t.Errorf("parsed message %q, want %q", entries[1].Message, "next")
+	}
+}
+
+func TestParseJSONContinuation(t *testing.T) {
+	messages := []string{
+		"request failed\n{\"error\": \"denied\"}",
+		"request failed\n{invalid",
+		"body\n{\n  \"a\": 1\n}",
+	}
+
+	buf := new(bytes.Buffer)
+	l := NewLogger(buf)
+	for _, message := range messages {
+		if _, err := l.Info(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := Parse(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(messages) {
+		t.Fatalf("parsed %d entries, want %d", len(entries), len(messages))
+	}
+	for i, e := range entries {
+		if e.Message != messages[i] {
+			t.Errorf("parsed message %q, want %q", e.Message, messages[i])
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Pinger is implemented by writers and sinks which can verify that they are able to accept messages, e.g.
+// that log file exists or network client is connected
+type Pinger interface {
+	// Ping returns error if messages can not be written.
+	Ping(ctx context.Context) error
+}
+
+// Ping verifies that main writer and all additional outputs are writable, so health of logging can be
+// included in readiness probes. Writers and sinks are checked by their Ping method, *os.File writers are
+// checked to be open; others are assumed to be healthy. Errors of all outputs are joined.
+func (l *Logger) Ping(ctx context.Context) error {
+	if l.summary.shutdown.Load() {
+		return ErrLoggerShutdown
+	}
+
+	l.mu.Lock()
+	writer, outputs := l.writer(), append([]*Output(nil), l.Outputs...)
+	l.mu.Unlock()
+
+	var errs []error
+	if err := ping(ctx, writer); err != nil {
+		errs = append(errs, fmt.Errorf("ping log writer: %w", err))
+	}
+
+	for i, output := range outputs {
+		var target any = output.Writer
+		if output.Sink != nil {
+			target = output.Sink
+		}
+
+		if err := ping(ctx, target); err != nil {
+			errs = append(errs, fmt.Errorf("ping log output %d: %w", i+1, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ping verifies that writer or sink `v` is able to accept messages.
+func ping(ctx context.Context, v any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case Pinger:
+		return v.Ping(ctx)
+	case *os.File:
+		_, err := v.Stat()
+		return err
+	}
+
+	return nil
+}
+
+// Ping implements Pinger. It reports error if file is closed, removed, e.g. by external rotation, or can not
+// be reopened after failed rotation.
+func (file *File) Ping(ctx context.Context) error {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.closed {
+		return os.ErrClosed
+	}
+	if file.f == nil {
+		return file.open()
+	}
+
+	if _, err := os.Stat(file.Path); err != nil {
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	return nil
+}
+
+// Ping implements Pinger by pinging underlying writer.
+func (tw *TimeoutWriter) Ping(ctx context.Context) error {
+	tw.mu.RLock()
+	closed := tw.closed
+	tw.mu.RUnlock()
+
+	if closed {
+		return ErrSinkClosed
+	}
+
+	return ping(ctx, tw.w)
+}
+
+// Ping implements Pinger by pinging underlying writer.
+func (cw *CoalescingWriter) Ping(ctx context.Context) error {
+	return ping(ctx, cw.w)
+}
+
+// Ping implements Pinger by pinging underlying writer.
+func (ew *EncryptedWriter) Ping(ctx context.Context) error {
+	return ping(ctx, ew.w)
+}
+
+// Ping implements Pinger. It reports error if sink is closed.
+func (s *BatchSink) Ping(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrSinkClosed
+	}
+
+	return nil
+}
+
+// Ping implements Pinger. It reports error if sink is closed or client is not connected to broker.
+func (s *MQTTSink) Ping(ctx context.Context) error {
+	if err := s.BatchSink.Ping(ctx); err != nil {
+		return err
+	}
+
+	if !s.client.IsConnected() {
+		return errors.New("mqtt client is not connected")
+	}
+
+	return nil
+}
+
+// Ping implements Pinger. It reports error if sink is closed or database is not reachable.
+func (s *SQLSink) Ping(ctx context.Context) error {
+	if err := s.BatchSink.Ping(ctx); err != nil {
+		return err
+	}
+
+	return s.db.PingContext(ctx)
+}
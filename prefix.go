@@ -0,0 +1,89 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PrefixFormat defines how level prefix of non-terminal text output is written
+type PrefixFormat int
+
+const (
+	// PrefixPipe writes `|INF|`-like prefixes
+	PrefixPipe PrefixFormat = iota
+
+	// PrefixBracket writes `[INFO ]`-like prefixes padded to the same width
+	PrefixBracket
+
+	// PrefixColon writes `INFO: `-like prefixes padded to the same width
+	PrefixColon
+
+	// PrefixNone writes no prefix
+	PrefixNone
+)
+
+// prefixWidth is a width of longest level name
+const prefixWidth = 5
+
+// prefixes contains precomputed prefixes of log levels in each format
+var prefixes = func() (prefixes [PrefixNone + 1][LogLevelProgress + 1]string) {
+	for format := range prefixes {
+		for level := range prefixes[format] {
+			prefixes[format][level] = renderPrefix(PrefixFormat(format), LogLevel(level))
+		}
+	}
+
+	return prefixes
+}()
+
+// formatPrefix returns prefix of log level `logLevel` in format `format`.
+func formatPrefix(format PrefixFormat, logLevel LogLevel) string {
+	if format >= 0 && int(format) < len(prefixes) && logLevel >= 0 && int(logLevel) < len(prefixes[format]) {
+		return prefixes[format][logLevel]
+	}
+
+	return renderPrefix(format, logLevel)
+}
+
+// levelPrefix returns `|INF|`-like prefix of log level.
+func levelPrefix(logLevel LogLevel) string {
+	return formatPrefix(PrefixPipe, logLevel)
+}
+
+// renderPrefix renders prefix of log level `logLevel` in format `format`.
+func renderPrefix(format PrefixFormat, logLevel LogLevel) string {
+	name := strings.ToUpper(logLevel.String())
+
+	switch format {
+	case PrefixBracket:
+		return fmt.Sprintf("[%-*s]", prefixWidth, name)
+	case PrefixColon:
+		return fmt.Sprintf("%-*s", prefixWidth+1, name+":")
+	case PrefixNone:
+		return ""
+	}
+
+	return fmt.Sprintf("|%s|", levelSymbol(logLevel))
+}
+
+// gutterCache holds gutter bars rendered with level styles, it is shared by logger and loggers derived from it
+type gutterCache struct {
+	// rendered bars by style
+	bars sync.Map
+}
+
+// render returns gutter bar rendered with style `style`. Styles are cached by pointer, so they are replaced
+// rather than modified in place.
+func (c *gutterCache) render(style *lipgloss.Style) string {
+	if bar, ok := c.bars.Load(style); ok {
+		return bar.(string)
+	}
+
+	bar := style.Render(gutterChar)
+	c.bars.Store(style, bar)
+
+	return bar
+}
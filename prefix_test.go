@@ -0,0 +1,83 @@
+package simplelog
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestFormatPrefix(t *testing.T) {
+	tests := []struct {
+		format PrefixFormat
+		level  LogLevel
+		want   string
+	}{
+		{PrefixPipe, LogLevelInfo, "|INF|"},
+		{PrefixPipe, LogLevel(100), "|???|"},
+		{PrefixBracket, LogLevelWarn, "[WARN ]"},
+		{PrefixColon, LogLevelError, "ERROR:"},
+		{PrefixNone, LogLevelFatal, ""},
+	}
+
+	for _, test := range tests {
+		if got := formatPrefix(test.format, test.level); got != test.want {
+			t.Errorf("formatPrefix(%d, %d) = %q, want %q", test.format, test.level, got, test.want)
+		}
+	}
+
+	for format := range prefixes {
+		for level := range prefixes[format] {
+			if got, want := prefixes[format][level], renderPrefix(PrefixFormat(format), LogLevel(level)); got != want {
+				t.Errorf("cached prefix of format %d and level %d is %q, want %q", format, level, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkFormatPrefix(b *testing.B) {
+	formats := []struct {
+		name   string
+		format PrefixFormat
+	}{
+		{"Pipe", PrefixPipe},
+		{"Bracket", PrefixBracket},
+		{"Colon", PrefixColon},
+	}
+
+	for _, f := range formats {
+		b.Run(f.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				formatPrefix(f.format, LogLevel(i%int(LogLevelFatal+1)))
+			}
+		})
+		b.Run(f.name+"Uncached", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				renderPrefix(f.format, LogLevel(i%int(LogLevelFatal+1)))
+			}
+		})
+	}
+}
+
+func BenchmarkGutter(b *testing.B) {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.ANSI256)
+	style := renderer.NewStyle().Foreground(lipgloss.Color("9"))
+	gutters := new(gutterCache)
+
+	b.Run("Cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			gutters.render(&style)
+		}
+	})
+	b.Run("Uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			style.Render(gutterChar)
+		}
+	})
+}
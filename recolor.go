@@ -0,0 +1,80 @@
+package simplelog
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Recolor reads log written in plain text or JSON format of this package from `r` and writes it to `w`
+// rendered with default terminal styles. Lines which are not log entries are written as is, continuation
+// lines of multi-line messages are styled like their first line.
+func Recolor(r io.Reader, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	logger := NewLogger(bw)
+	logger.out.Store(&mainWriter{w: bw, isTerminal: true, defaultsTerminal: true})
+	logger.NoColor = false
+	logger.TimeFormat = defaultFileTimestampFormat
+
+	renderer := lipgloss.NewRenderer(w)
+	renderer.SetColorProfile(termenv.ANSI256)
+	logger.setRenderer(renderer)
+
+	s := &Scanner{TimeFormat: defaultFileTimestampFormat}
+
+	lines := bufio.NewScanner(r)
+	lines.Buffer(nil, maxAuditLineSize)
+
+	lastLevel := LogLevel(-1)
+	for lines.Scan() {
+		line := lines.Text()
+
+		entry, isText, err := s.parseLine(line)
+		if err != nil {
+			if style, exists := logger.Styles[lastLevel]; exists && style != nil {
+				line = style.Render(line)
+			}
+			if _, err := bw.WriteString(line + "\n"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if isText {
+			entry.Message, entry.Fields = splitTextFields(entry.Message)
+			extractCaller(entry)
+		}
+
+		if _, err := logger.write(entry); err != nil {
+			return err
+		}
+		lastLevel = entry.Level
+	}
+
+	if err := lines.Err(); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// setRenderer binds all styles of logger to renderer `r`.
+func (l *Logger) setRenderer(r *lipgloss.Renderer) {
+	l.TimeStampStyle = l.TimeStampStyle.Renderer(r)
+	l.FieldStyle = l.FieldStyle.Renderer(r)
+
+	styles := make(map[LogLevel]*lipgloss.Style, len(l.Styles))
+	for level, style := range l.Styles {
+		if style != nil {
+			s := style.Renderer(r)
+			style = &s
+		}
+		styles[level] = style
+	}
+	l.Styles = styles
+
+	l.tags.renderer = r
+}
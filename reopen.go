@@ -0,0 +1,112 @@
+package simplelog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// openFiles is a set of log files opened by package
+var openFiles = struct {
+	files map[*File]struct{}
+	mu    sync.Mutex
+}{files: make(map[*File]struct{})}
+
+// Reopen closes and reopens log file by its path. It allows external tools like logrotate to move log file
+// away without truncating it.
+func (file *File) Reopen() error {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.closed {
+		return os.ErrClosed
+	}
+
+	if file.f != nil {
+		if err := file.f.Close(); err != nil {
+			return fmt.Errorf("close log file: %w", err)
+		}
+		file.f = nil
+	}
+
+	return file.open()
+}
+
+// Reopen reopens all log files used by logger as writer or additional outputs.
+func (l *Logger) Reopen() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []error
+
+	if file, ok := l.writer().(*File); ok {
+		errs = append(errs, file.Reopen())
+	}
+
+	for _, output := range l.Outputs {
+		if file, ok := output.Writer.(*File); ok {
+			errs = append(errs, file.Reopen())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReopenFiles reopens all log files opened by package and not closed yet.
+func ReopenFiles() error {
+	openFiles.mu.Lock()
+	defer openFiles.mu.Unlock()
+
+	var errs []error
+	for file := range openFiles.files {
+		errs = append(errs, file.Reopen())
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReopenOnSIGHUP installs SIGHUP handler which reopens all log files opened by package. Reopen errors are
+// passed to `errorHandler` if it is not nil. Returned function uninstalls handler.
+func ReopenOnSIGHUP(errorHandler func(err error)) (stop func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-c:
+				if err := ReopenFiles(); err != nil && errorHandler != nil {
+					errorHandler(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(c)
+			close(done)
+		})
+	}
+}
+
+// registerFile adds log file to set of files opened by package.
+func registerFile(file *File) {
+	openFiles.mu.Lock()
+	openFiles.files[file] = struct{}{}
+	openFiles.mu.Unlock()
+}
+
+// unregisterFile removes log file from set of files opened by package.
+func unregisterFile(file *File) {
+	openFiles.mu.Lock()
+	delete(openFiles.files, file)
+	openFiles.mu.Unlock()
+}
@@ -0,0 +1,36 @@
+package simplelog
+
+import "time"
+
+// Replay writes entries `entries`, e.g. read by Parse, to logger outputs keeping original intervals between
+// entries divided by `speed`: 2 replays twice as fast, non-positive speed writes entries without delays.
+// Entries are stamped with current time of writing, entries below logger level are skipped. It is useful
+// for demos, tutorials and tests of log consumers.
+func (l *Logger) Replay(entries []Entry, speed float64) (n int, err error) {
+	for i := range entries {
+		if i > 0 && speed > 0 {
+			if d := entries[i].Time.Sub(entries[i-1].Time); d > 0 {
+				time.Sleep(time.Duration(float64(d) / speed))
+			}
+		}
+
+		if l.summary.shutdown.Load() {
+			return n, ErrLoggerShutdown
+		}
+
+		if !l.enabled(entries[i].Level) {
+			continue
+		}
+
+		entry := entries[i]
+		entry.Time = l.now()
+
+		m, err := l.emit(&entry)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,23 @@
+package simplelog
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewRequestID returns short random ID of 8 hex digits for grouping messages of single request.
+func NewRequestID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// NewRequestLogger returns logger which records new random request ID as `req` field on each message, so
+// interleaved messages of concurrent requests can be grouped without tracing infrastructure.
+func (l *Logger) NewRequestLogger() *Logger {
+	c := l.clone()
+	c.fields = mergeFields(c.fields, []Field{{"req", NewRequestID()}})
+
+	return c
+}
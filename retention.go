@@ -0,0 +1,270 @@
+package simplelog
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Retention is a policy of cleanup of old log files
+type Retention struct {
+	// MaxAge is a maximum age of old log files. Older files are removed.
+	MaxAge time.Duration
+
+	// MaxTotalSize is a maximum total size of old log files in bytes. Oldest files are removed until
+	// total size fits.
+	MaxTotalSize int64
+
+	// Compress enables gzip compression of old log files
+	Compress bool
+
+	// Interval is a period of cleanup, default is one hour
+	Interval time.Duration
+
+	// ErrorHandler is called on cleanup errors
+	ErrorHandler func(err error)
+}
+
+// enabled reports whether retention policy does anything.
+func (r Retention) enabled() bool {
+	return r.MaxAge > 0 || r.MaxTotalSize > 0 || r.Compress
+}
+
+// SetRetention starts background cleanup of old log files (rotated backups and previous date-stamped files)
+// according to policy `r`. Cleanup runs immediately and then periodically until log file is closed or
+// retention is replaced. Zero policy stops cleanup.
+func (file *File) SetRetention(r Retention) {
+	file.mu.Lock()
+	stop := file.stopRetention
+	file.stopRetention = nil
+	file.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+
+	if !r.enabled() {
+		return
+	}
+
+	if r.Interval <= 0 {
+		r.Interval = defaultRetentionInterval
+	}
+
+	done := make(chan struct{})
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(r.Interval)
+		defer ticker.Stop()
+
+		for {
+			if err := file.Cleanup(r); err != nil && r.ErrorHandler != nil {
+				r.ErrorHandler(err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	file.mu.Lock()
+	file.stopRetention = func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+	file.mu.Unlock()
+}
+
+// oldFile represents old log file found during cleanup
+type oldFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// Cleanup compresses and removes old log files according to policy `r` once.
+func (file *File) Cleanup(r Retention) error {
+	// path of current file can not change while cleanup mutex is locked
+	file.cleanupMu.Lock()
+	defer file.cleanupMu.Unlock()
+
+	files, err := oldFiles(file.Path, file.Pattern, file.DateFormat)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	if r.Compress {
+		for i, f := range files {
+			if strings.HasSuffix(f.path, compressedExt) {
+				continue
+			}
+
+			compressed, err := compressFile(f)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			files[i] = compressed
+		}
+	}
+
+	// newest files first
+	slices.SortFunc(files, func(a, b oldFile) int { return b.modTime.Compare(a.modTime) })
+
+	var totalSize int64
+	now := time.Now()
+	for _, f := range files {
+		totalSize += f.size
+
+		if (r.MaxAge > 0 && now.Sub(f.modTime) > r.MaxAge) || (r.MaxTotalSize > 0 && totalSize > r.MaxTotalSize) {
+			if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("remove old log file: %w", err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// oldFiles returns old log files of log file `current`: its rotated backups and other date-stamped files
+// matching pattern `pattern` with dates in format `layout`.
+func oldFiles(current, pattern, layout string) ([]oldFile, error) {
+	globs := []string{current + ".*"}
+	if pattern != "" {
+		glob := strings.ReplaceAll(pattern, datePlaceholder, "*")
+		globs = append(globs, glob, glob+".*")
+	}
+
+	seen := make(map[string]bool)
+	var files []oldFile
+	for _, glob := range globs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, fmt.Errorf("list old log files: %w", err)
+		}
+
+		for _, path := range matches {
+			if path == current || seen[path] {
+				continue
+			}
+			if glob == globs[0] && !backupSuffix(strings.TrimPrefix(path, current)) {
+				continue
+			}
+			if glob != globs[0] && !dateStamped(path, pattern, layout) {
+				continue
+			}
+			seen[path] = true
+
+			fi, err := os.Lstat(path)
+			if err != nil || !fi.Mode().IsRegular() {
+				continue
+			}
+
+			files = append(files, oldFile{path, fi.Size(), fi.ModTime()})
+		}
+	}
+
+	return files, nil
+}
+
+// dateStamped reports whether file `path` is date-stamped file of pattern `pattern` with date in format
+// `layout` or its rotated or compressed backup.
+func dateStamped(path, pattern, layout string) bool {
+	prefix, _, _ := strings.Cut(filepath.Clean(pattern), datePlaceholder)
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+
+	for end := len(prefix) + 1; end <= len(path); end++ {
+		date := path[len(prefix):end]
+		if _, err := time.Parse(layout, date); err != nil {
+			continue
+		}
+
+		name := filepath.Clean(strings.ReplaceAll(pattern, datePlaceholder, date))
+		if path == name || (strings.HasPrefix(path, name) && backupSuffix(path[len(name):])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backupSuffix reports whether `s` is a suffix which rotation and compression add to log file name: `.N`,
+// `.N.gz` or `.gz`.
+func backupSuffix(s string) bool {
+	s = strings.TrimSuffix(s, compressedExt)
+	if s == "" {
+		return true
+	}
+
+	n, ok := strings.CutPrefix(s, ".")
+	if !ok || n == "" {
+		return false
+	}
+	for _, c := range n {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compressFile compresses file `f` with gzip and removes original file.
+func compressFile(f oldFile) (oldFile, error) {
+	src, err := os.Open(f.path)
+	if err != nil {
+		return f, fmt.Errorf("compress old log file: %w", err)
+	}
+	defer src.Close()
+
+	path := f.path + compressedExt
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return f, fmt.Errorf("compress old log file: %w", err)
+	}
+
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if err == nil {
+		err = zw.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return f, fmt.Errorf("compress old log file: %w", err)
+	}
+
+	os.Chtimes(path, f.modTime, f.modTime)
+	src.Close()
+	os.Remove(f.path)
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return f, fmt.Errorf("compress old log file: %w", err)
+	}
+
+	return oldFile{path, fi.Size(), f.modTime}, nil
+}
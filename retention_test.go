@@ -0,0 +1,84 @@
+package simplelog
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDateStamped(t *testing.T) {
+	tests := []struct {
+		path, pattern string
+		want          bool
+	}{
+		{"app-2026-10-14.log", "app-{date}.log", true},
+		{"app-2026-10-14.log.1", "app-{date}.log", true},
+		{"app-2026-10-14.log.1.gz", "app-{date}.log", true},
+		{"app-old.log", "app-{date}.log", false},
+		{"app-2026-10-14-copy.log", "app-{date}.log", false},
+		{"app-2026-13-01.log", "app-{date}.log", false},
+		{"app-2026-10-14.logx", "app-{date}.log", false},
+		{"app-2026-10-14.log.lock", "app-{date}.log", false},
+		{"app-2026-10-14.log.bak", "app-{date}.log", false},
+		{"app-2026-10-14.log.1.bak", "app-{date}.log", false},
+		{"logs/app-2026-10-14.log", "./logs/app-{date}.log", true},
+		{"2026-10-14", "{date}", true},
+		{"2026-10-14.gz", "{date}", true},
+		{"notes", "{date}", false},
+	}
+
+	for _, test := range tests {
+		if got := dateStamped(test.path, test.pattern, defaultFileDateFormat); got != test.want {
+			t.Errorf("dateStamped(%q, %q) = %v, want %v", test.path, test.pattern, got, test.want)
+		}
+	}
+}
+
+func TestOldFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app-2026-10-13.log", "app-2026-10-13.log.gz", "app-2026-10-14.log", "app-2026-10-14.log.1", "app-backup.log", "app-2026-10-14.log.tmp"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := oldFiles(filepath.Join(dir, "app-2026-10-14.log"), filepath.Join(dir, "app-{date}.log"), defaultFileDateFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, f := range files {
+		got = append(got, filepath.Base(f.path))
+	}
+	slices.Sort(got)
+
+	if want := []string{"app-2026-10-13.log", "app-2026-10-13.log.gz", "app-2026-10-14.log.1"}; !slices.Equal(got, want) {
+		t.Errorf("got old files %v, want %v", got, want)
+	}
+}
+
+func TestOldBackups(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app.log", "app.log.1", "app.log.2.gz", "app.log.lock", "app.log.bak", "app.log.1.tmp", "app.log."} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := oldFiles(filepath.Join(dir, "app.log"), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, f := range files {
+		got = append(got, filepath.Base(f.path))
+	}
+	slices.Sort(got)
+
+	if want := []string{"app.log.1", "app.log.2.gz"}; !slices.Equal(got, want) {
+		t.Errorf("got old files %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+package simplelog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// RingBuffer is a sink which keeps last entries in memory, e.g. for support bundles
+type RingBuffer struct {
+	entries []*Entry
+
+	// index of oldest entry when buffer is full
+	next int
+
+	mu sync.Mutex
+}
+
+// NewRingBuffer returns new ring buffer which keeps last `size` entries.
+func NewRingBuffer(size int) *RingBuffer {
+	return &RingBuffer{entries: make([]*Entry, 0, max(size, 1))}
+}
+
+// WriteEntry implements Sink.
+func (r *RingBuffer) WriteEntry(e *Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, e)
+		return nil
+	}
+
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+
+	return nil
+}
+
+// Entries returns up to `n` last entries from oldest to newest. All entries are returned if `n` is not
+// positive.
+func (r *RingBuffer) Entries(n int) []*Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := make([]*Entry, 0, len(r.entries))
+	entries = append(entries, r.entries[r.next:]...)
+	entries = append(entries, r.entries[:r.next]...)
+
+	if n > 0 && n < len(entries) {
+		entries = entries[len(entries)-n:]
+	}
+
+	return entries
+}
+
+// KeepLast adds ring buffer output which keeps last `n` messages in memory and returns it.
+func (l *Logger) KeepLast(n int) *RingBuffer {
+	r := NewRingBuffer(n)
+	l.AddSink(r)
+
+	return r
+}
+
+// ringBuffer returns first ring buffer output of logger.
+func (l *Logger) ringBuffer() *RingBuffer {
+	for _, output := range l.Outputs {
+		if r, ok := output.Sink.(*RingBuffer); ok {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// SnapshotTo writes up to `lastN` last messages kept by ring buffer output (see KeepLast) to file `path` in
+// text format, e.g. for support bundles. All kept messages are written if `lastN` is not positive.
+func (l *Logger) SnapshotTo(path string, lastN int) error {
+	r := l.ringBuffer()
+	if r == nil {
+		return errors.New("logger has no ring buffer output")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create log snapshot: %w", err)
+	}
+
+	if err := writeEntries(f, r.Entries(lastN)); err != nil {
+		f.Close()
+		return fmt.Errorf("write log snapshot: %w", err)
+	}
+
+	return f.Close()
+}
+
+// writeEntries writes entries to `w` in text format.
+func writeEntries(w io.Writer, entries []*Entry) error {
+	enc := NewTextEncoder()
+	for _, e := range entries {
+		b, err := enc.Encode(e)
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package simplelog
+
+import (
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// sampler holds counters of sampled messages shared by logger and all loggers derived from it
+type sampler struct {
+	// number of messages by call site and sampling interval
+	counts sync.Map
+}
+
+// sampleKey identifies counter of call site sampled with interval
+type sampleKey struct {
+	pc uintptr
+	n  uint64
+}
+
+// Sampled returns logger which writes only share `rate` of Trace and Debug messages of each call site, e.g.
+// `log.Sampled(0.01).Tracef(...)` writes first and then every 100th message of hot code path. Counters of
+// call sites are shared by all loggers derived from l, so the logger can be created on each call. Messages
+// of other levels are not sampled. Rate of 1 or more disables sampling, rate of 0 or less drops all Trace
+// and Debug messages.
+func (l *Logger) Sampled(rate float64) *Logger {
+	c := l.clone()
+
+	switch {
+	case rate >= 1:
+		c.sampling = false
+	case rate <= 0:
+		c.sampling, c.sampleEvery = true, 0
+	default:
+		c.sampling, c.sampleEvery = true, uint64(math.Round(1/rate))
+	}
+
+	return c
+}
+
+// sample reports whether message of current call site must be written when every `n`-th message is
+// written. Zero `n` drops all messages.
+func (s *sampler) sample(n uint64) bool {
+	if n == 0 {
+		return false
+	}
+
+	key := sampleKey{callerPC(), n}
+
+	v, ok := s.counts.Load(key)
+	if !ok {
+		v, _ = s.counts.LoadOrStore(key, new(atomic.Uint64))
+	}
+
+	return (v.(*atomic.Uint64).Add(1)-1)%n == 0
+}
+
+// callerPC returns program counter of first stack frame outside of this package.
+func callerPC() uintptr {
+	var pcs [16]uintptr
+	n := runtime.Callers(3, pcs[:])
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) || !more {
+			return frame.PC
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package simplelog
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Sanitize is a set of rules applied to message text before it is written to main writer. Rules protect
+// terminal and width computations of progress and alignment from unexpected message content.
+type Sanitize int
+
+const (
+	// SanitizeUTF8 replaces invalid UTF-8 sequences with U+FFFD
+	SanitizeUTF8 Sanitize = 1 << iota
+
+	// SanitizeCR replaces carriage returns, which move cursor to line start and garble output, with `\r`
+	SanitizeCR
+
+	// SanitizeControl replaces control characters other than new line, tab, carriage return and escape with
+	// Go escape sequences, e.g. `\x00`
+	SanitizeControl
+
+	// SanitizeANSI strips ANSI escape sequences, including hyperlinks made by Logger.Link
+	SanitizeANSI
+
+	// SanitizeNone disables sanitization
+	SanitizeNone Sanitize = 0
+)
+
+// defaultSanitize is a default set of sanitization rules
+const defaultSanitize = SanitizeUTF8 | SanitizeCR | SanitizeControl
+
+// defaultJSONSanitize is a default set of sanitization rules of JSONEncoder
+const defaultJSONSanitize = SanitizeUTF8 | SanitizeANSI
+
+// sanitize returns text `s` with sanitization rules `rules` applied.
+func sanitize(s string, rules Sanitize) string {
+	if rules == SanitizeNone {
+		return s
+	}
+
+	if rules&SanitizeUTF8 != 0 && !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "�")
+	}
+
+	if rules&SanitizeANSI != 0 && strings.IndexByte(s, '\x1b') >= 0 {
+		// escapes which do not start valid sequences are left by parser
+		s = strings.ReplaceAll(ansi.Strip(s), "\x1b", "")
+	}
+
+	if rules&(SanitizeCR|SanitizeControl) == 0 || !strings.ContainsFunc(s, unicode.IsControl) {
+		return s
+	}
+
+	sb := new(strings.Builder)
+	for _, r := range s {
+		switch {
+		case r == '\r' && rules&SanitizeCR != 0:
+			sb.WriteString(`\r`)
+		case r == '\r', r == '\n', r == '\t', r == '\x1b':
+			sb.WriteRune(r)
+		case unicode.IsControl(r) && rules&SanitizeControl != 0:
+			q := strconv.QuoteRune(r)
+			sb.WriteString(q[1 : len(q)-1])
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+// sanitizeFields returns fields `fields` with sanitization rules `rules` applied to string values. Slice is
+// copied if any value is changed.
+func sanitizeFields(fields []Field, rules Sanitize) []Field {
+	if rules == SanitizeNone {
+		return fields
+	}
+
+	var sanitized []Field
+	for i, f := range fields {
+		v, changed := sanitizeValue(f.Value, rules)
+		if !changed {
+			continue
+		}
+
+		if sanitized == nil {
+			sanitized = slices.Clone(fields)
+		}
+		sanitized[i].Value = v
+	}
+
+	if sanitized == nil {
+		return fields
+	}
+
+	return sanitized
+}
+
+// sanitizeValue returns field value `v` with sanitization rules `rules` applied if it is a string and reports
+// whether it is changed. Values of other types are not compared, they may be not comparable.
+func sanitizeValue(v any, rules Sanitize) (any, bool) {
+	switch v := v.(type) {
+	case string:
+		s := sanitize(v, rules)
+		return s, s != v
+	case rawValue:
+		s := sanitize(string(v), rules)
+		return rawValue(s), s != string(v)
+	case Hyperlink:
+		h := Hyperlink{URL: sanitize(v.URL, rules), Text: sanitize(v.Text, rules)}
+		return h, h != v
+	case semanticValue:
+		if s, ok := v.value.(string); ok {
+			sanitized := sanitize(s, rules)
+			v.value = sanitized
+			return v, sanitized != s
+		}
+	}
+
+	return v, false
+}
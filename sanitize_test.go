@@ -0,0 +1,100 @@
+package simplelog
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestSanitizeFields(t *testing.T) {
+	e := &Entry{
+		Level:   LogLevelInfo,
+		Message: "line\r\x1b[31mred",
+		Fields:  []Field{{"path", "a\x00b"}, URL("url", "http://x/\x1b[0m"), {"n", 1}},
+	}
+
+	tests := []struct {
+		name string
+		enc  Encoder
+		want string
+	}{
+		{"text", &TextEncoder{Sanitize: defaultSanitize}, "|INF| line\\r\x1b[31mred path=a\\x00b url=http://x/\x1b[0m n=1\n"},
+		{"text none", &TextEncoder{}, "|INF| line\r\x1b[31mred path=\"a\\x00b\" url=http://x/\x1b[0m n=1\n"},
+		{"json", &JSONEncoder{Sanitize: defaultJSONSanitize}, `{"level":"info","msg":"line\rred","path":"a\u0000b","url":"http://x/","n":1}` + "\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := test.enc.Encode(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(b); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	if e.Fields[0].Value != "a\x00b" {
+		t.Errorf("fields of entry are modified: %q", e.Fields[0].Value)
+	}
+}
+
+func TestSanitizeDisplay(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(buf)
+	l.out.Store(&mainWriter{w: l.Output(), isTerminal: true})
+	l.NoColor = true
+	l.NoHyperlinks = true
+	l.Sanitize |= SanitizeANSI
+
+	l.KV(LogLevelInfo, "key\x1b[2J", "\x1b[31mred")
+
+	req, err := http.NewRequest(http.MethodPost, "http://example.com/", strings.NewReader("\x1b]0;title\x07body"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.DumpRequest(LogLevelInfo, req, true)
+
+	if out := buf.String(); strings.ContainsAny(out, "\x1b\x07") || !strings.Contains(out, "red") || !strings.Contains(out, "body") {
+		t.Errorf("got output %q", out)
+	}
+}
+
+func FuzzSanitize(f *testing.F) {
+	for _, s := range []string{"", "plain", "a\rb", "\x00\x07\x7f", "\x1b[31mred\x1b[0m", "\xff\xfe", "\x1b]8;;http://x\x07link\x1b]8;;\x07", "\u0085\u009b"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got := sanitize(s, defaultSanitize)
+		if !utf8.ValidString(got) {
+			t.Fatalf("sanitize(%q) = %q is not valid UTF-8", s, got)
+		}
+		if strings.ContainsFunc(got, func(r rune) bool { return unicode.IsControl(r) && r != '\n' && r != '\t' && r != '\x1b' }) {
+			t.Fatalf("sanitize(%q) = %q contains control characters", s, got)
+		}
+		if again := sanitize(got, defaultSanitize); again != got {
+			t.Fatalf("sanitize is not idempotent: %q -> %q -> %q", s, got, again)
+		}
+
+		if got := sanitize(s, defaultSanitize|SanitizeANSI); strings.ContainsRune(got, '\x1b') {
+			t.Fatalf("sanitize(%q) with SanitizeANSI = %q contains escape", s, got)
+		}
+
+		if got := sanitize(s, SanitizeNone); got != s {
+			t.Fatalf("sanitize(%q) with SanitizeNone = %q", s, got)
+		}
+	})
+}
+
+func TestSanitizeUncomparableFields(t *testing.T) {
+	fields := []Field{{"ids", []int{1, 2}}, {"tags", map[string]string{"a": "b"}}}
+
+	if got := sanitizeFields(fields, defaultSanitize); &got[0] != &fields[0] {
+		t.Error("fields without strings are copied")
+	}
+}
@@ -0,0 +1,105 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FieldKind is a semantic kind of field value which determines its terminal style
+type FieldKind int
+
+const (
+	// FieldDuration is a kind of duration values
+	FieldDuration FieldKind = iota + 1
+
+	// FieldError is a kind of error values
+	FieldError
+
+	// FieldURL is a kind of URL values
+	FieldURL
+
+	// FieldPath is a kind of file path values
+	FieldPath
+
+	// FieldTrue is a kind of true boolean values
+	FieldTrue
+
+	// FieldFalse is a kind of false boolean values
+	FieldFalse
+)
+
+// semanticValue is a field value of semantic kind. Outputs other than terminal record underlying value.
+type semanticValue struct {
+	kind  FieldKind
+	value any
+}
+
+// String returns text representation of underlying value.
+func (s semanticValue) String() string {
+	return fmt.Sprint(s.value)
+}
+
+// Dur returns field of duration `d`.
+func Dur(key string, d time.Duration) Field {
+	return Field{key, semanticValue{FieldDuration, d}}
+}
+
+// Err returns `error` field of error `err` recorded as error text.
+func Err(err error) Field {
+	var value any
+	if err != nil {
+		value = err.Error()
+	}
+
+	return Field{"error", semanticValue{FieldError, value}}
+}
+
+// URL returns field of URL `u`.
+func URL(key, u string) Field {
+	return Field{key, semanticValue{FieldURL, u}}
+}
+
+// Path returns field of file path `p`.
+func Path(key, p string) Field {
+	return Field{key, semanticValue{FieldPath, p}}
+}
+
+// Bool returns field of boolean `b` styled by its value.
+func Bool(key string, b bool) Field {
+	kind := FieldFalse
+	if b {
+		kind = FieldTrue
+	}
+
+	return Field{key, semanticValue{kind, b}}
+}
+
+// valueStyle returns terminal style of field value `v` or nil if value has no semantic kind or its kind
+// has no style.
+func (l *Logger) valueStyle(v any) *lipgloss.Style {
+	s, ok := v.(semanticValue)
+	if !ok {
+		return nil
+	}
+
+	return l.FieldStyles[s.kind]
+}
+
+// styleFields returns `key=value` representation of fields `fields` rendered with field style and values of
+// semantic kinds rendered with their styles.
+func (l *Logger) styleFields(fields []Field) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		if style := l.valueStyle(f.Value); style != nil {
+			parts[i] = l.FieldStyle.Render(f.Key+"=") + style.Render(formatValue(f.Value))
+			continue
+		}
+
+		parts[i] = l.FieldStyle.Render(f.Key + "=" + formatValue(f.Value))
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,19 @@
+package simplelog
+
+import "sync"
+
+// SetMutex makes logger and loggers derived from it later serialize writes with mutex `mu`. Loggers and other
+// writers of the same file descriptor which share mutex never interleave their lines. Must be called before
+// logger is used concurrently.
+func (l *Logger) SetMutex(mu *sync.Mutex) {
+	l.mu = mu
+}
+
+// ShareOutput makes logger write to the same output as logger `other` sharing its mutex and progress line
+// state, so progress line of one logger is cleared by messages of another and their lines never interleave.
+// Must be called before logger is used concurrently.
+func (l *Logger) ShareOutput(other *Logger) {
+	l.out = other.out
+	l.mu = other.mu
+	l.progress = other.progress
+}
@@ -0,0 +1,79 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrLoggerShutdown is returned by logger after Shutdown is called
+var ErrLoggerShutdown = errors.New("logger is shut down")
+
+// Shutdown stops accepting new messages by logger and all loggers sharing its output, waits for messages
+// being written, closes sinks to drain their queues and syncs writers. Open windows of Dedup are closed
+// first, so numbers of suppressed duplicates are written. It returns error if shutdown does not complete
+// before `ctx` is done, if closing or syncing fails or if any messages were dropped.
+func (l *Logger) Shutdown(ctx context.Context) error {
+	if d := l.dedup; d != nil {
+		d.flush()
+	}
+	l.summary.shutdown.Store(true)
+
+	done := make(chan error, 1)
+	go func() {
+		// sinks are closed after messages being written reach them
+		l.summary.stop()
+		done <- l.shutdown()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("shut down logger: %w", ctx.Err())
+	}
+}
+
+// shutdown closes sinks and syncs writers of logger.
+func (l *Logger) shutdown() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []error
+
+	dropped := l.summary.dropped
+	for _, output := range l.Outputs {
+		if output.Sink == nil {
+			continue
+		}
+
+		if c, ok := output.Sink.(interface{ Close() error }); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("close log sink: %w", err))
+			}
+		}
+
+		if d, ok := output.Sink.(interface{ Dropped() int64 }); ok {
+			dropped += d.Dropped()
+		}
+	}
+
+	if err := syncWriter(l.writer()); err != nil && !l.terminal() {
+		errs = append(errs, fmt.Errorf("sync log output: %w", err))
+	}
+	for _, output := range l.Outputs {
+		if output.Writer == nil {
+			continue
+		}
+
+		if err := syncWriter(output.Writer); err != nil {
+			errs = append(errs, fmt.Errorf("sync log output: %w", err))
+		}
+	}
+
+	if dropped > 0 {
+		errs = append(errs, fmt.Errorf("%d log messages dropped", dropped))
+	}
+
+	return errors.Join(errs...)
+}
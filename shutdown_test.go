@@ -0,0 +1,93 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingEncoder is an encoder which blocks until it is released
+type blockingEncoder struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (enc *blockingEncoder) Encode(e *Entry) ([]byte, error) {
+	enc.entered <- struct{}{}
+	<-enc.release
+
+	return []byte(e.Message + "\n"), nil
+}
+
+// closingSink is a sink which records written entries and fails writes after close
+type closingSink struct {
+	mu      sync.Mutex
+	closed  bool
+	written []string
+}
+
+func (s *closingSink) WriteEntry(e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.New("write after close")
+	}
+	s.written = append(s.written, e.Message)
+
+	return nil
+}
+
+func (s *closingSink) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+func TestShutdownWaitsForWrites(t *testing.T) {
+	enc := &blockingEncoder{entered: make(chan struct{}, 1), release: make(chan struct{})}
+	s := new(closingSink)
+
+	l := NewLogger(io.Discard)
+	l.AddOutput(enc, io.Discard)
+	l.AddSink(s)
+
+	written := make(chan error, 1)
+	go func() {
+		_, err := l.Info("message")
+		written <- err
+	}()
+	<-enc.entered
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- l.Shutdown(context.Background())
+	}()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("shutdown completed while message is being written: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if _, err := l.Info("late"); !errors.Is(err, ErrLoggerShutdown) {
+		t.Errorf("got error %v after shutdown started, want %v", err, ErrLoggerShutdown)
+	}
+
+	close(enc.release)
+	if err := <-written; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.written) != 1 || !s.closed {
+		t.Errorf("sink received %q, closed: %v", s.written, s.closed)
+	}
+}
@@ -1,11 +1,13 @@
 package simplelog
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -13,51 +15,255 @@ import (
 )
 
 type Logger struct {
+	// Writer is main writer of logger.
+	//
+	// Deprecated: Writer is not updated when output is swapped by other logger sharing it and assigning it
+	// does not change output. Use Output and SwapOutput instead.
 	Writer io.Writer
 
 	// timestamp format
 	TimeFormat string
 
+	// DebugTimeFormat is a timestamp format of Debug and Trace messages, e.g. `15:04:05.000` for millisecond
+	// precision where it matters. TimeFormat is used if empty.
+	DebugTimeFormat string
+
 	// timestamp style
 	TimeStampStyle lipgloss.Style
 
-	// log level styles
+	// style of message fields
+	FieldStyle lipgloss.Style
+
+	// log level styles, they should be replaced rather than modified in place after logger is used
 	Styles map[LogLevel]*lipgloss.Style
 
+	// styles of field values of semantic kinds, e.g. made by Dur or Err
+	FieldStyles map[FieldKind]*lipgloss.Style
+
+	// disable colors of terminal output
+	NoColor bool
+
 	// strip message from spaces before output
 	StripMessages bool
 
-	// Minimum log level of messages
-	Level LogLevel
+	// Minimum log levels of messages of specific packages. Package names are the same as in CallerTagPackage
+	// mode. Logger level is used for packages not listed here.
+	Modules map[string]LogLevel
+
+	// Format of messages written to non-terminal output
+	Format Format
+
+	// Encoder of messages written to main writer. If set, it overrides Format and is used for terminal
+	// output too.
+	Encoder Encoder
+
+	// Layout is a template of line with `{time}`, `{level}`, `{name}`, `{message}` and `{fields}`
+	// placeholders, e.g. `{level} {time} {message} {fields}`. Components missing in template are not
+	// written; level prefix is not written to terminal. Default layout is
+	// `{time} {level} {name} {message} {fields}`.
+	Layout string
+
+	// PrefixFormat is a format of level prefix of non-terminal text output. Only default format is parsed
+	// back by Scanner.
+	PrefixFormat PrefixFormat
 
 	// Marker of trimmed messages
 	TrimMarker string
 
+	// Sanitize is a set of rules applied to message text and string field values written to main writer.
+	// Default rules replace invalid UTF-8, carriage returns and control characters, ANSI escape sequences
+	// are kept.
+	Sanitize Sanitize
+
+	// MaxEntryBytes is a maximum size of message text with fields in text format in bytes. Larger messages
+	// are handled according to OversizePolicy. Zero value disables limit.
+	MaxEntryBytes int
+
+	// OversizePolicy defines how messages larger than MaxEntryBytes are handled
+	OversizePolicy OversizePolicy
+
+	// disable progress messages
+	NoProgress bool
+
+	// ProgressLevel is a log level of progress messages used for level filtering, so progress is hidden
+	// when minimum log level is higher. Default is Info.
+	ProgressLevel LogLevel
+
+	// FieldsColumn is a screen column where fields of terminal messages start. Fields are written on
+	// continuation line if message text reaches the column or fields do not fit terminal. Zero value
+	// disables alignment.
+	FieldsColumn int
+
+	// FieldsRight enables right alignment of fields of terminal messages to terminal edge
+	FieldsRight bool
+
+	// MaxFieldBytes is a maximum number of bytes of []byte field values shown on terminal as hex. Zero value
+	// means no limit.
+	MaxFieldBytes int
+
+	// NoHyperlinks disables OSC 8 hyperlinks in terminal output
+	NoHyperlinks bool
+
+	// CallerURL is a template of URL of caller hyperlinks in terminal output with `{path}` and `{line}`
+	// placeholders, e.g. `vscode://file/{path}:{line}`. Default is `file://` URL of caller file.
+	CallerURL string
+
+	// Gutter enables terminal style where level is shown by colored bar at the start of each line and
+	// message text is not colored
+	Gutter bool
+
+	// Bell enables ringing of terminal bell on Error and Fatal messages
+	Bell bool
+
+	// Translator returns localized text of message `key` formatted with arguments `args`. It is used by
+	// Translate for terminal output.
+	Translator func(key string, args ...any) string
+
+	// Clock returns time of messages. Current time is used if nil.
+	Clock func() time.Time
+
+	// TerminalWidth overrides detected terminal width if positive
+	TerminalWidth int
+
 	// MinProgressUpdatePeriod is used to limit progress update speed
 	MinProgressUpdatePeriod time.Duration
 
-	// is output to terminal
-	isTerminal bool
+	// CallerTag enables tagging of messages by package or goroutine which emitted them
+	CallerTag CallerTag
 
-	// last written progress message length
-	lastProgressLineWidth int
+	// GoroutineID enables recording of goroutine ID on each message
+	GoroutineID bool
 
-	// Timestamp of last written progress message
-	lastProgressUpdateTime time.Time
+	// ProcessInfo enables recording of hostname, PID and executable name on each message.
+	// Metadata is not written to terminal.
+	ProcessInfo bool
+
+	// AppVersion is recorded on each message with process metadata
+	AppVersion string
+
+	// ReportCaller enables recording of caller file and line on each message
+	ReportCaller bool
+
+	// VerboseFields enables recording of fields marked by Verbose on messages of all levels
+	VerboseFields bool
+
+	// Outputs are additional destinations of messages with their own encoders
+	Outputs []*Output
+
+	// Fingerprint enables recording of `fingerprint` field on Error and Fatal messages: hash of message
+	// template and error types which groups identical errors with different variable data
+	Fingerprint bool
+
+	// ErrorHandler is called on errors of additional outputs
+	ErrorHandler func(err error)
+
+	// SyncLevel is a minimum log level of messages after which writers are synced to disk
+	SyncLevel LogLevel
+
+	// SyncInterval enables syncing of writers on write if last sync was earlier than interval ago
+	SyncInterval time.Duration
+
+	// StackDumpOnFatal enables writing of all goroutine stacks to non-terminal outputs by Fatal methods
+	StackDumpOnFatal bool
+
+	// SummaryOnExit enables printing of summary before exit by Fatal methods and Exit
+	SummaryOnExit bool
+
+	// FatalHookTimeout is a maximum time functions registered by OnFatal may delay exit
+	FatalHookTimeout time.Duration
+
+	// main writer shared by derived loggers, replaced by SwapOutput
+	out *atomic.Pointer[mainWriter]
+
+	// minimum log level of messages, read without locking
+	level *atomic.Int32
+
+	// state of progress line shared by all loggers writing to the same output
+	progress *progressState
+
+	// time of last sync of writers
+	lastSync *time.Time
+
+	// statistics of written messages
+	summary *summaryState
+
+	// is quiet mode enabled and settings to restore after it is disabled
+	quiet        bool
+	quietRestore quietState
+
+	// fields attached to each message
+	fields []Field
+
+	// style of message text overriding level style
+	style *lipgloss.Style
+
+	// task which collects warnings and errors written by logger
+	task *taskState
+
+	// buffer of deferred logger
+	deferred *deferredSink
+
+	// sampling of Trace and Debug messages by call site: every `sampleEvery`-th message is written, counters
+	// are shared by derived loggers
+	sampling    bool
+	sampleEvery uint64
+	sampler     *sampler
+
+	// suppression of duplicate messages
+	dedup *dedupState
+
+	// functions called before exit by Fatal methods
+	hooks *fatalHooks
+
+	// writer owned by logger which is closed by Close
+	owned io.Closer
+
+	// name of message source and tag column used to render it
+	name string
+	tags *tagColumn
+
+	// gutter bars rendered with level styles
+	gutters *gutterCache
 
 	// mutex to prevent race conditions
 	mu *sync.Mutex
 }
 
+// progressState represents state of last written progress message
+type progressState struct {
+	// last written progress message length
+	lineWidth int
+
+	// Timestamp of last written progress message
+	updateTime time.Time
+}
+
 // NewLogger returns new logger which writes messages to `w`.
 func NewLogger(w io.Writer) *Logger {
 	logger := &Logger{
-		Writer:         w,
-		TimeStampStyle: defaultTimestampStyle,
-		Styles:         make(map[LogLevel]*lipgloss.Style),
-		Level:          defaulLogLevel,
-		TrimMarker:     defaultTrimMarker,
-		mu:             new(sync.Mutex)}
+		Writer:           w,
+		out:              new(atomic.Pointer[mainWriter]),
+		TimeStampStyle:   defaultTimestampStyle,
+		FieldStyle:       defaultFieldStyle,
+		Styles:           make(map[LogLevel]*lipgloss.Style),
+		FieldStyles:      make(map[FieldKind]*lipgloss.Style),
+		level:            new(atomic.Int32),
+		TrimMarker:       defaultTrimMarker,
+		MaxFieldBytes:    defaultMaxFieldBytes,
+		Sanitize:         defaultSanitize,
+		ProgressLevel:    defaultProgressLevel,
+		SyncLevel:        defaultSyncLevel,
+		FatalHookTimeout: defaultFatalHookTimeout,
+		hooks:            new(fatalHooks),
+		sampler:          new(sampler),
+		progress:         new(progressState),
+		lastSync:         new(time.Time),
+		summary:          newSummaryState(),
+		tags:             newTagColumn(),
+		gutters:          new(gutterCache),
+		mu:               new(sync.Mutex)}
+
+	logger.level.Store(int32(defaulLogLevel))
 
 	logger.Styles[LogLevelTrace] = &defaultTraceStyle
 	logger.Styles[LogLevelDebug] = &defaultDebugStyle
@@ -67,11 +273,28 @@ func NewLogger(w io.Writer) *Logger {
 	logger.Styles[LogLevelFatal] = &defaultFatalStyle
 	logger.Styles[LogLevelProgress] = &defaultProgressStyle
 
-	if f, ok := w.(*os.File); ok {
-		logger.isTerminal = term.IsTerminal(int(f.Fd()))
+	logger.FieldStyles[FieldDuration] = &defaultDurationStyle
+	logger.FieldStyles[FieldError] = &defaultErrorValueStyle
+	logger.FieldStyles[FieldURL] = &defaultURLStyle
+	logger.FieldStyles[FieldPath] = &defaultPathStyle
+	logger.FieldStyles[FieldTrue] = &defaultTrueStyle
+	logger.FieldStyles[FieldFalse] = &defaultFalseStyle
+
+	out := newMainWriter(w)
+	out.defaultsTerminal = out.isTerminal
+	logger.out.Store(out)
+
+	logger.setErrorHandlerOf(w)
+
+	if _, exists := os.LookupEnv("NO_COLOR"); exists {
+		logger.NoColor = true
+	}
+
+	if DetectContainer && !out.isTerminal && (w == os.Stdout || w == os.Stderr) && inContainer() {
+		logger.Format = FormatContainer
 	}
 
-	if logger.isTerminal {
+	if out.isTerminal {
 		logger.TimeFormat = defaultTerminalTimestampFormat
 	} else {
 		logger.TimeFormat = defaultFileTimestampFormat
@@ -99,13 +322,93 @@ func levelSymbol(logLevel LogLevel) string {
 	return "???"
 }
 
+// mainWriter represents main writer of logger. It is immutable: SwapOutput replaces it as a whole, so
+// messages rendered before swap are detected by comparing pointers.
+type mainWriter struct {
+	w io.Writer
+
+	// is output to terminal
+	isTerminal bool
+
+	// was default timestamp format of logger chosen for terminal
+	defaultsTerminal bool
+
+	// is terminal rendering disabled by DeterministicMode
+	noTerminal bool
+}
+
+// newMainWriter returns main writer `w` with detected terminal.
+func newMainWriter(w io.Writer) *mainWriter {
+	out := &mainWriter{w: w}
+	if f, ok := w.(*os.File); ok {
+		out.isTerminal = term.IsTerminal(int(f.Fd()))
+	}
+
+	return out
+}
+
+// Output returns main writer of logger.
+func (l *Logger) Output() io.Writer {
+	return l.writer()
+}
+
+// writer returns main writer of logger.
+func (l *Logger) writer() io.Writer {
+	return l.out.Load().w
+}
+
+// terminal reports whether main writer is terminal.
+func (l *Logger) terminal() bool {
+	return l.out.Load().isTerminal
+}
+
+// colored reports whether output should be colored.
+func (l *Logger) colored() bool {
+	return l.terminal() && !l.NoColor
+}
+
+// setWriter replaces output writer of logger and all loggers sharing it and updates terminal detection. Must
+// be called with locked mutex.
+func (l *Logger) setWriter(w io.Writer) {
+	prev := l.out.Load()
+
+	out := newMainWriter(w)
+	out.defaultsTerminal, out.noTerminal = prev.defaultsTerminal, prev.noTerminal
+	out.isTerminal = out.isTerminal && !out.noTerminal
+	l.out.Store(out)
+
+	l.setErrorHandlerOf(w)
+
+	*l.progress = progressState{}
+}
+
+// SwapOutput replaces output writer with `w` and returns previous one, e.g. to retarget logging after
+// daemonizing. Progress line of previous writer is cleared and terminal detection is re-run for `w`.
+func (l *Logger) SwapOutput(w io.Writer) io.Writer {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.clearProgress()
+
+	old := l.writer()
+	l.setWriter(w)
+	l.Writer = w
+	l.summary.outputClosed = false
+
+	return old
+}
+
 // getWidth returns current terminal width.
 func (l *Logger) getWidth() int {
-	if !l.isTerminal {
+	if !l.terminal() {
 		return 0
 	}
 
-	f, ok := l.Writer.(*os.File)
+	if l.TerminalWidth > 0 {
+		return l.TerminalWidth
+	}
+
+	f, ok := l.writer().(*os.File)
 	if !ok {
 		return 0
 	}
@@ -139,11 +442,9 @@ func (l *Logger) Error(a ...any) (n int, err error) {
 }
 
 func (l *Logger) Fatal(a ...any) {
-	l.mu.Lock()
 	l.Print(LogLevelFatal, a...)
-	l.mu.Unlock()
 
-	os.Exit(1)
+	l.fatalExit()
 }
 
 func (l *Logger) Traceln(a ...any) (n int, err error) {
@@ -167,21 +468,17 @@ func (l *Logger) Errorln(a ...any) (n int, err error) {
 }
 
 func (l *Logger) Fatalln(a ...any) {
-	l.Println(LogLevelError, a...)
+	l.Println(LogLevelFatal, a...)
 
-	if f, ok := l.Writer.(*os.File); ok {
-		f.Sync()
-	}
-
-	os.Exit(1)
+	l.fatalExit()
 }
 
 func (l *Logger) Tracef(format string, a ...any) (n int, err error) {
-	return l.Printf(LogLevelInfo, format, a...)
+	return l.Printf(LogLevelTrace, format, a...)
 }
 
 func (l *Logger) Debugf(format string, a ...any) (n int, err error) {
-	return l.Printf(LogLevelInfo, format, a...)
+	return l.Printf(LogLevelDebug, format, a...)
 }
 
 func (l *Logger) Infof(format string, a ...any) (n int, err error) {
@@ -199,106 +496,468 @@ func (l *Logger) Errorf(format string, a ...any) (n int, err error) {
 func (l *Logger) Fatalf(format string, a ...any) {
 	l.Printf(LogLevelFatal, format, a...)
 
-	os.Exit(1)
+	l.fatalExit()
 }
 
-func (l *Logger) timestamp(t time.Time) string {
-	if l.TimeFormat == "" {
+// Level returns minimum log level of messages written to main writer and additional outputs without own
+// level.
+func (l *Logger) Level() LogLevel {
+	return LogLevel(l.level.Load())
+}
+
+// SetLevel sets minimum log level of messages written to main writer and additional outputs without own
+// level. It is safe to call concurrently with writing messages.
+func (l *Logger) SetLevel(level LogLevel) {
+	l.level.Store(int32(level))
+}
+
+// minLevel returns logger level or level of caller module.
+func (l *Logger) minLevel() LogLevel {
+	minLevel := l.Level()
+
+	if len(l.Modules) > 0 {
+		if level, exists := l.Modules[packageName(caller().Function)]; exists {
+			minLevel = level
+		}
+	}
+
+	return minLevel
+}
+
+// enabled reports whether messages of level `logLevel` should be written to main writer or any additional
+// output.
+func (l *Logger) enabled(logLevel LogLevel) bool {
+	minLevel := l.minLevel()
+
+	if logLevel == LogLevelProgress {
+		return l.ProgressLevel >= minLevel
+	}
+
+	if logLevel >= minLevel {
+		return true
+	}
+
+	for _, output := range l.Outputs {
+		if output.Level != nil && logLevel >= *output.Level {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writerEnabled reports whether messages of level `logLevel` should be written to main writer when logger
+// level is `minLevel`.
+func (l *Logger) writerEnabled(logLevel, minLevel LogLevel) bool {
+	if logLevel == LogLevelProgress {
+		logLevel = l.ProgressLevel
+	}
+
+	return logLevel >= minLevel
+}
+
+// now returns current time of logger clock.
+func (l *Logger) now() time.Time {
+	if l.Clock != nil {
+		return l.Clock()
+	}
+
+	return time.Now()
+}
+
+func (l *Logger) timestamp(t time.Time, logLevel LogLevel) string {
+	format := l.TimeFormat
+
+	// default format follows main writer replaced by writer of other kind
+	if out := l.out.Load(); out.isTerminal != out.defaultsTerminal {
+		switch {
+		case out.isTerminal && format == defaultFileTimestampFormat:
+			format = defaultTerminalTimestampFormat
+		case !out.isTerminal && format == defaultTerminalTimestampFormat:
+			format = defaultFileTimestampFormat
+		}
+	}
+	if l.DebugTimeFormat != "" && format != "" && logLevel <= LogLevelDebug {
+		format = l.DebugTimeFormat
+	}
+
+	if format == "" {
 		return ""
 	}
 
-	if !l.isTerminal {
-		return t.Format(l.TimeFormat)
+	if !l.colored() {
+		return t.Format(format)
 	}
 
-	return l.TimeStampStyle.Render(t.Format(l.TimeFormat))
+	return l.TimeStampStyle.Render(t.Format(format))
 }
 
 func (l *Logger) prefix(logLevel LogLevel) string {
-	return fmt.Sprintf("|%s|", levelSymbol(logLevel))
+	return formatPrefix(l.PrefixFormat, logLevel)
 }
 
 func (l *Logger) Print(logLevel LogLevel, a ...any) (n int, err error) {
-	return l.p(logLevel, fmt.Sprint(a...))
+	s := fmt.Sprint(a...)
+	template := func() string { return messageTemplate(a) }
+	causes, display := l.errorTree(logLevel, s, a)
+	return l.log(logLevel, s, display, append(l.fingerprintFields(logLevel, template, a), causes...))
 }
 
 func (l *Logger) Progressf(format string, a ...any) (n int, err error) {
-	if !l.isTerminal {
+	if !l.progressEnabled() {
 		return 0, nil
 	}
 
 	return l.p(LogLevelProgress, fmt.Sprintf(format, a...))
 }
 
+// progressEnabled reports whether progress is drawn in place on terminal. It is disabled by NoProgress and
+// quiet mode.
+func (l *Logger) progressEnabled() bool {
+	return l.terminal() && !l.NoProgress
+}
+
 func (l *Logger) Printf(logLevel LogLevel, format string, a ...any) (n int, err error) {
-	return l.p(logLevel, fmt.Sprintf(format, a...))
+	var s string
+	wrapped := a
+	if strings.Contains(format, "%w") {
+		// errors of `%w` verbs are formatted and wrapped like fmt.Errorf does, so their chain is shown
+		err := fmt.Errorf(format, a...)
+		s, wrapped = err.Error(), []any{err}
+	} else {
+		s = fmt.Sprintf(format, a...)
+	}
+	template := func() string { return format }
+	causes, display := l.errorTree(logLevel, s, wrapped)
+	return l.log(logLevel, s, display, append(l.fingerprintFields(logLevel, template, a), causes...))
 }
 
 func (l *Logger) Println(logLevel LogLevel, a ...any) (n int, err error) {
 	s := fmt.Sprintln(a...)
-	return l.p(logLevel, s[:len(s)-1])
+	s = s[:len(s)-1]
+	template := func() string { return messageTemplate(a) }
+	causes, display := l.errorTree(logLevel, s, a)
+	return l.log(logLevel, s, display, append(l.fingerprintFields(logLevel, template, a), causes...))
 }
 
 func (l *Logger) p(logLevel LogLevel, s string) (n int, err error) {
-	timeStamp := time.Now()
+	template := func() string { return normalizeTemplate(s) }
+	return l.log(logLevel, s, "", l.fingerprintFields(logLevel, template, nil))
+}
 
-	if logLevel == LogLevelProgress {
-		if l.MinProgressUpdatePeriod > 0 && timeStamp.Sub(l.lastProgressUpdateTime) < l.MinProgressUpdatePeriod {
-			return
+// log writes message `s` of log level `logLevel` with additional fields `fields`. If `display` is not
+// empty, terminal shows it instead of message and additional fields.
+func (l *Logger) log(logLevel LogLevel, s, display string, fields []Field) (n int, err error) {
+	if l.summary.shutdown.Load() {
+		return 0, ErrLoggerShutdown
+	}
+
+	timeStamp := l.now()
+
+	if logLevel == LogLevelProgress && l.MinProgressUpdatePeriod > 0 {
+		l.mu.Lock()
+		throttled := timeStamp.Sub(l.progress.updateTime) < l.MinProgressUpdatePeriod
+		if !throttled {
+			l.progress.updateTime = timeStamp
 		}
+		l.mu.Unlock()
 
-		l.lastProgressUpdateTime = timeStamp
+		if throttled {
+			return 0, nil
+		}
+	}
+
+	if !l.enabled(logLevel) {
+		return 0, nil
 	}
 
-	if logLevel < l.Level {
+	if l.sampling && logLevel <= LogLevelDebug && !l.sampler.sample(l.sampleEvery) {
 		return 0, nil
 	}
 
+	entry := l.newEntry(timeStamp, logLevel, s)
+	fields = l.verboseFields(logLevel, fields)
+	entry.Fields = append(entry.Fields, fields...)
+	if display != "" {
+		entry.display = display
+		entry.hidden = len(fields)
+	}
+
+	if l.dedup != nil && l.dedup.suppress(l, entry) {
+		return 0, nil
+	}
+
+	if l.MaxEntryBytes <= 0 {
+		return l.emit(entry)
+	}
+
+	entries, err := l.limit(entry)
+	for _, e := range entries {
+		m, werr := l.emit(e)
+		n += m
+		if werr != nil {
+			return n, werr
+		}
+	}
+
+	return n, err
+}
+
+// emit writes entry to main writer and additional outputs.
+func (l *Logger) emit(entry *Entry) (n int, err error) {
+	if !l.summary.begin() {
+		return 0, ErrLoggerShutdown
+	}
+
+	minLevel := l.minLevel()
+	toWriter := l.writerEnabled(entry.Level, minLevel)
+
+	// formatting is done before locking, so only writes are serialized
+	encoded := l.encodeOutputs(entry, minLevel)
+	out := l.out.Load()
+	var line *line
+	if toWriter {
+		line, err = l.render(entry, false)
+	}
+
 	l.mu.Lock()
-	defer l.mu.Unlock()
+	if toWriter && out != l.out.Load() {
+		// main writer was swapped during rendering
+		line, err = l.render(entry, true)
+	}
+	l.writeOutputs(entry, encoded, minLevel)
+	if toWriter && err == nil {
+		n, err = l.writeLine(entry, line)
+	}
+	if err != nil {
+		l.summary.dropped++
+	}
+	if errors.Is(err, ErrWriteTimeout) || errors.Is(err, ErrQueueFull) {
+		l.handleError(fmt.Errorf("write log message: %w", err))
+	}
+	if errors.Is(err, os.ErrClosed) {
+		l.outputClosed(err)
+	}
+	l.summary.add(entry, n)
+	if l.task != nil {
+		l.task.add(entry)
+	}
+	l.sync(entry)
+	exceeded := l.summary.exceeded(entry)
+	l.mu.Unlock()
+	l.summary.end()
 
-	msg := &msg{
-		TimeStamp: l.timestamp(timeStamp),
-		Text:      s,
+	if exceeded != nil {
+		exceeded()
 	}
 
-	if l.StripMessages {
-		msg.Text = strings.TrimSpace(msg.Text)
+	return n, err
+}
+
+// line represents message rendered for main writer
+type line struct {
+	// first line of message and the rest of it including line terminator
+	head, tail string
+
+	// width of first line and terminal width used to pad it over previous progress line
+	width, termWidth int
+
+	// is padding over previous progress line needed
+	pad bool
+
+	// is encoding deferred until writing because encoder depends on order of entries
+	deferred bool
+}
+
+// write writes entry to main writer. Must be called with locked mutex.
+func (l *Logger) write(e *Entry) (n int, err error) {
+	line, err := l.render(e, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return l.writeLine(e, line)
+}
+
+// render renders entry for main writer. Encoders which depend on order of entries are not called unless
+// `locked` is true.
+func (l *Logger) render(e *Entry, locked bool) (*line, error) {
+	if enc := l.formatEncoder(); enc != nil {
+		if !locked && isOrdered(enc) {
+			return &line{deferred: true}, nil
+		}
+
+		b, err := enc.Encode(e)
+		if err != nil {
+			return nil, err
+		}
+
+		return &line{head: string(b)}, nil
 	}
 
-	if l.isTerminal {
-		if logLevel == LogLevelProgress {
-			msg.fit(l.getWidth(), l.TrimMarker)
+	fields := entryFields(e, true)
+	if l.terminal() {
+		fields = terminalEntryFields(e)
+		if l.MaxFieldBytes > 0 {
+			fields = bytesFields(fields, BytesHex, l.MaxFieldBytes)
 		}
+	}
+	// fields are sanitized before escape sequences of hyperlinks are added
+	fields = sanitizeFields(fields, l.Sanitize)
+	if l.hyperlinks() {
+		fields = terminalFields(l.callerFields(e, fields))
+	}
+
+	msg := &msg{
+		TimeStamp: l.timestamp(e.Time, e.Level),
+		Text:      sanitize(e.Message, l.Sanitize),
+		Fields:    formatFields(fields),
+		Layout:    l.Layout,
+	}
 
+	if e.Source != "" {
+		msg.Tag = l.tags.render(e.Source, l.colored())
+	}
+
+	if !l.terminal() {
+		msg.Text = quoteText(msg.Text)
+	}
+
+	if l.terminal() && e.display != "" {
+		// continuation lines are aligned with first line of message
+		indent := 0
 		if msg.TimeStamp != "" {
-			msg.TimeStamp = l.TimeStampStyle.Render(msg.TimeStamp)
+			indent += lipgloss.Width(msg.TimeStamp) + 1
 		}
-		style, exists := l.Styles[logLevel]
-		if exists && style != nil {
-			msg.Text = l.Styles[logLevel].Render(msg.Text)
+		if msg.Tag != "" {
+			indent += lipgloss.Width(msg.Tag) + 1
+		}
+
+		msg.Text = strings.ReplaceAll(e.display, "\n", "\n"+strings.Repeat(" ", indent))
+	}
+
+	termWidth := l.getWidth()
+
+	gutter := ""
+	if l.terminal() && l.Gutter {
+		gutter = gutterChar
+	}
+
+	if l.terminal() {
+		if e.Level == LogLevelProgress {
+			if gutter != "" {
+				msg.fit(termWidth-lipgloss.Width(gutter)-1, l.TrimMarker)
+			} else {
+				msg.fit(termWidth, l.TrimMarker)
+			}
+		}
+
+		if l.colored() {
+			style, exists := l.Styles[e.Level]
+			cached := l.style == nil
+			if l.style != nil {
+				override := *l.style
+				if exists && style != nil {
+					override = override.Inherit(*style)
+				}
+				style, exists = &override, true
+			}
+			switch {
+			case exists && style != nil && gutter != "" && cached:
+				gutter = l.gutters.render(style)
+			case exists && style != nil && gutter != "":
+				gutter = style.Render(gutter)
+			case exists && style != nil:
+				msg.Text = style.Render(msg.Text)
+			}
+			if msg.Fields != "" {
+				msg.Fields = l.styleFields(fields)
+			}
 		}
 	} else {
-		msg.Prefix = l.prefix(logLevel)
+		msg.Prefix = l.prefix(e.Level)
 	}
 
 	str := msg.String()
-	w := lipgloss.Width(str)
+	if l.terminal() && e.Level != LogLevelProgress && msg.Fields != "" && (l.FieldsColumn > 0 || l.FieldsRight) {
+		str = msg.aligned(l.FieldsColumn, l.FieldsRight, termWidth)
+	}
+	if gutter != "" {
+		str = gutter + " " + strings.ReplaceAll(str, "\n", "\n"+gutter+" ")
+	}
 
-	if l.isTerminal && w < l.lastProgressLineWidth {
-		str += strings.Repeat(" ", max(min(l.lastProgressLineWidth-w, l.getWidth()-w), 0))
-		l.lastProgressLineWidth = 0
+	// progress line is cleared by padding of first line of message
+	head, rest, multiline := strings.Cut(str, "\n")
+
+	ln := &line{
+		head:      head,
+		width:     lipgloss.Width(head),
+		termWidth: termWidth,
+		pad:       l.terminal()}
+
+	if multiline {
+		ln.tail = "\n" + rest
 	}
 
-	if logLevel == LogLevelProgress {
-		l.lastProgressLineWidth = w
+	if l.terminal() && l.Bell && e.Level >= LogLevelError && e.Level != LogLevelProgress {
+		ln.tail += "\a"
 	}
 
-	if logLevel == LogLevelProgress {
-		str += "\r"
+	if e.Level == LogLevelProgress {
+		ln.tail += "\r"
 	} else {
-		str += "\n"
+		ln.tail += "\n"
+	}
+
+	return ln, nil
+}
+
+// writeLine writes rendered entry to main writer updating progress line state. Must be called with locked
+// mutex.
+func (l *Logger) writeLine(e *Entry, ln *line) (n int, err error) {
+	deferred := ln.deferred
+	if deferred {
+		if ln, err = l.render(e, true); err != nil {
+			return 0, err
+		}
+	}
+
+	head := ln.head
+	if ln.pad && ln.termWidth > 0 && l.progress.lineWidth > ln.termWidth {
+		// progress line wrapped by terminal is erased row by row instead of padding
+		head = eraseRows(l.progress.lineWidth, ln.termWidth) + head
+		l.progress.lineWidth = 0
+	}
+	if ln.pad && ln.width < l.progress.lineWidth {
+		padding := l.progress.lineWidth - ln.width
+		if ln.termWidth > 0 {
+			padding = min(padding, ln.termWidth-ln.width)
+		}
+		head += strings.Repeat(" ", max(padding, 0))
+		l.progress.lineWidth = 0
+	}
+
+	if ln.pad && e.Level == LogLevelProgress {
+		l.progress.lineWidth = ln.width
+	}
+
+	n, err = l.writer().Write([]byte(head + ln.tail))
+	if err == nil {
+		l.summary.writer.record(n, l.now())
+	} else if deferred {
+		rollback(l.formatEncoder())
+	}
+
+	return n, err
+}
+
+// eraseRows returns escape sequence which moves cursor from last row of line of width `width` wrapped by
+// terminal of width `termWidth` to its first row and erases screen below.
+func eraseRows(width, termWidth int) string {
+	s := "\r"
+	if rows := (width + termWidth - 1) / termWidth; rows > 1 {
+		s += fmt.Sprintf("\x1b[%dA", rows-1)
 	}
 
-	return l.Writer.Write([]byte(str))
+	return s + "\x1b[J"
 }
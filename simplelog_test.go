@@ -0,0 +1,77 @@
+package simplelog
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// benchmarkParallel logs messages with fields from parallel goroutines. If `serialized` is true, whole call
+// is done under mutex like it was before formatting was moved out of logger mutex.
+func benchmarkParallel(b *testing.B, l *Logger, serialized bool) {
+	err := errors.New("connection refused")
+	l = l.With(Field{"host", "example.com"}, Field{"attempt", 3})
+	mu := new(sync.Mutex)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if serialized {
+				mu.Lock()
+			}
+			l.Info("request failed: ", err)
+			if serialized {
+				mu.Unlock()
+			}
+		}
+	})
+}
+
+// newTextBenchmarkLogger returns logger writing text messages to io.Discard.
+func newTextBenchmarkLogger() *Logger {
+	return NewLogger(io.Discard)
+}
+
+// newJSONBenchmarkLogger returns logger writing text messages and additional JSON output to io.Discard.
+func newJSONBenchmarkLogger() *Logger {
+	l := NewLogger(io.Discard)
+	l.AddOutput(NewJSONEncoder(), io.Discard)
+
+	return l
+}
+
+func BenchmarkParallelText(b *testing.B) {
+	benchmarkParallel(b, newTextBenchmarkLogger(), false)
+}
+
+func BenchmarkParallelTextSerialized(b *testing.B) {
+	benchmarkParallel(b, newTextBenchmarkLogger(), true)
+}
+
+func BenchmarkParallelJSON(b *testing.B) {
+	benchmarkParallel(b, newJSONBenchmarkLogger(), false)
+}
+
+func BenchmarkParallelJSONSerialized(b *testing.B) {
+	benchmarkParallel(b, newJSONBenchmarkLogger(), true)
+}
+
+func TestSwapOutput(t *testing.T) {
+	first, second := new(bytes.Buffer), new(bytes.Buffer)
+	l := NewLogger(first)
+	derived := l.With(Field{"id", 1})
+
+	if got := derived.SwapOutput(second); got != first {
+		t.Errorf("SwapOutput returned %v, want previous writer", got)
+	}
+	if l.Output() != second || derived.Writer != second {
+		t.Error("output is not swapped")
+	}
+
+	l.Info("message")
+	if first.Len() != 0 || second.Len() == 0 {
+		t.Errorf("message is written to old writer: %q", first.String())
+	}
+}
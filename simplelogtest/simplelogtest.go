@@ -0,0 +1,67 @@
+// Package simplelogtest provides helpers for snapshot testing of simplelog output.
+package simplelogtest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nxshock/simplelog"
+)
+
+// update enables rewriting of golden files by Golden
+var update = flag.Bool("update", false, "update golden files of log output")
+
+// timestamps matches dates with times and times written by default and RFC 3339 formats
+var timestamps = regexp.MustCompile(`(\d{4}-\d{2}-\d{2}[ T])?\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?`)
+
+// Golden captures output written to main writer of logger `l` during call of `f`, replaces timestamps with
+// `<time>` and compares it with golden file `testdata/<test name>.golden`. If test is run with `-update` flag,
+// golden file is written instead. Output of loggers derived from `l` before or during call, e.g. by With, is
+// captured too as they share its writer; additional outputs are not captured. Writer of logger is restored
+// after call even if `f` panics or stops test.
+func Golden(t testing.TB, l *simplelog.Logger, f func()) {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	capture(l, buf, f)
+
+	got := Normalize(buf.String())
+	path := filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("create golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("log output differs from golden file %s\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// capture calls `f` with main writer of logger `l` replaced by `w`.
+func capture(l *simplelog.Logger, w *bytes.Buffer, f func()) {
+	old := l.SwapOutput(w)
+	defer l.SwapOutput(old)
+
+	f()
+}
+
+// Normalize returns log output `s` with timestamps replaced by `<time>`.
+func Normalize(s string) string {
+	return timestamps.ReplaceAllString(s, "<time>")
+}
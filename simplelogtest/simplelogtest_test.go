@@ -0,0 +1,33 @@
+package simplelogtest
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/nxshock/simplelog"
+)
+
+func TestGoldenDerivedLogger(t *testing.T) {
+	l := simplelog.NewLogger(io.Discard)
+	child := l.With(simplelog.Field{Key: "id", Value: 1})
+
+	Golden(t, l, func() {
+		l.Info("parent")
+		child.Info("child")
+	})
+}
+
+func TestCaptureRestoresOnPanic(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := simplelog.NewLogger(w)
+
+	func() {
+		defer func() { recover() }()
+		capture(l, new(bytes.Buffer), func() { panic("test") })
+	}()
+
+	if l.Output() != w {
+		t.Fatal("writer is not restored after panic")
+	}
+}
@@ -0,0 +1,128 @@
+package simplelog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sqlTimeFormat is a format of entry time stored in database, compatible with SQLite date functions
+const sqlTimeFormat = "2006-01-02 15:04:05.000000"
+
+// sqlIdentifier matches valid table names
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLSink is a sink which inserts entries to database table in batch transactions. Table has columns
+// `time` (UTC time in `2006-01-02 15:04:05.000000` format), `level` (numeric log level), `source`,
+// `message`, `fields` (JSON object) and `caller`; `time` and `level` columns are indexed.
+type SQLSink struct {
+	*BatchSink
+
+	db     *sql.DB
+	insert string
+}
+
+// NewSQLSink returns new sink which inserts entries to table `table` of database `db`, creating table and
+// its indexes if they do not exist. Database/sql driver is chosen by caller, e.g. SQLite driver. If
+// `numbered` is true, `$1`-style placeholders are used instead of `?`, as required by PostgreSQL.
+func NewSQLSink(db *sql.DB, table string, numbered bool, options BatchOptions) (*SQLSink, error) {
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %q", table)
+	}
+
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	time TEXT NOT NULL,
+	level INTEGER NOT NULL,
+	source TEXT NOT NULL,
+	message TEXT NOT NULL,
+	fields TEXT NOT NULL,
+	caller TEXT NOT NULL
+)`, table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_time ON %s (time)", table, table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_level ON %s (level)", table, table),
+	}
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return nil, fmt.Errorf("create log table: %w", err)
+		}
+	}
+
+	placeholders := []string{"?", "?", "?", "?", "?", "?"}
+	if numbered {
+		for i := range placeholders {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		}
+	}
+
+	s := &SQLSink{
+		db: db,
+		insert: fmt.Sprintf("INSERT INTO %s (time, level, source, message, fields, caller) VALUES (%s)",
+			table, strings.Join(placeholders, ", "))}
+
+	s.BatchSink = NewBatchSink(s.flush, options)
+
+	return s, nil
+}
+
+// flush inserts batch of entries in single transaction.
+func (s *SQLSink) flush(ctx context.Context, batch []*Entry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, s.insert)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range batch {
+		fields, err := sqlFields(e)
+		if err != nil {
+			return err
+		}
+
+		if _, err := stmt.ExecContext(ctx, e.Time.UTC().Format(sqlTimeFormat), int(e.Level), e.Source, e.Message, fields, e.CallerString()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// sqlFields returns fields of entry as JSON object.
+func sqlFields(e *Entry) (string, error) {
+	sb := new(strings.Builder)
+	sb.WriteRune('{')
+
+	for i, f := range e.Fields {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+
+		k, err := json.Marshal(f.Key)
+		if err != nil {
+			return "", err
+		}
+		value := fieldValue(f.Value)
+		v, err := json.Marshal(value)
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(value))
+		}
+
+		sb.Write(k)
+		sb.WriteRune(':')
+		sb.Write(v)
+	}
+
+	sb.WriteRune('}')
+
+	return sb.String(), nil
+}
@@ -0,0 +1,60 @@
+package simplelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSQLSink(t *testing.T) {
+	d := new(testDB)
+	s, err := NewSQLSink(d.open(), "logs", true, BatchOptions{Size: 10, Interval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2000, 1, 2, 3, 4, 5, 6000, time.UTC)
+	entries := []*Entry{
+		{Time: now, Level: LogLevelWarn, Source: "api", Message: "a", Fields: []Field{{"id", 7}, {"user", "bob"}}},
+		{Time: now, Level: LogLevelInfo, Message: "b", Fields: []Field{{"ch", make(chan int)}}},
+	}
+	for _, e := range entries {
+		if err := s.WriteEntry(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.execs) != 5 {
+		t.Fatalf("executed %d statements, want 5", len(d.execs))
+	}
+	if !strings.HasPrefix(d.execs[0].query, "CREATE TABLE IF NOT EXISTS logs") {
+		t.Errorf("executed %q, want table creation", d.execs[0].query)
+	}
+
+	inserts := d.execs[3:]
+	if want := "INSERT INTO logs (time, level, source, message, fields, caller) VALUES ($1, $2, $3, $4, $5, $6)"; inserts[0].query != want {
+		t.Errorf("executed %q, want %q", inserts[0].query, want)
+	}
+	if got := inserts[0].args; got[0] != "2000-01-02 03:04:05.000006" || got[1] != int(LogLevelWarn) || got[2] != "api" || got[3] != "a" || got[4] != `{"id":7,"user":"bob"}` {
+		t.Errorf("inserted %v", got)
+	}
+	if got := inserts[1].args[4].(string); !strings.HasPrefix(got, `{"ch":"0x`) {
+		t.Errorf("inserted fields %s, want unmarshallable value as string", got)
+	}
+	if d.commits != 1 {
+		t.Errorf("committed %d transactions, want 1", d.commits)
+	}
+}
+
+func TestSQLSinkInvalidTable(t *testing.T) {
+	d := new(testDB)
+	if _, err := NewSQLSink(d.open(), "logs; DROP TABLE users", false, BatchOptions{}); err == nil {
+		t.Error("invalid table name is accepted")
+	}
+	if len(d.execs) != 0 {
+		t.Errorf("executed %d statements, want 0", len(d.execs))
+	}
+}
@@ -0,0 +1,300 @@
+package simplelog
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sensitiveName matches names of SQL arguments and URL query parameters whose values are redacted by default
+var sensitiveName = regexp.MustCompile(`(?i)pass|secret|token|key|auth|signature|credential`)
+
+// sqlToken matches tokens of SQL query: string literals and quoted identifiers, placeholders, identifiers,
+// comparison operators and other characters
+var sqlToken = regexp.MustCompile(`'(?:[^']|'')*'|"(?:[^"]|"")*"|\$\d+|\?|[:@][A-Za-z_]\w*|[A-Za-z_][\w.]*|<>|!=|<=|>=|\S`)
+
+// LoggedDB is a database handle which writes executed queries with arguments, number of affected rows and
+// duration as Debug messages. Queries slower than SlowThreshold are written as Warn messages. Methods not
+// wrapped by LoggedDB are called on embedded handle without logging; transactions started by Begin and BeginTx
+// are logged too.
+type LoggedDB struct {
+	*sql.DB
+
+	// SlowThreshold is a minimum duration of queries written as Warn messages. Zero value disables it.
+	SlowThreshold time.Duration
+
+	// Redact returns value of query argument written to log instead of argument `v`. Name is a name of named
+	// argument or name of column positional argument is compared with or inserted to, e.g. `password` for
+	// `WHERE password = ?` or `INSERT INTO users (name, password) VALUES ($1, $2)`; it is empty if it is
+	// not known. Default function replaces values of arguments with names like `password` or `token`.
+	Redact func(name string, v any) any
+
+	logger *Logger
+}
+
+// LoggedTx is a transaction which queries are logged like queries of LoggedDB which started it. Methods not
+// wrapped by LoggedTx are called on embedded transaction without logging.
+type LoggedTx struct {
+	*sql.Tx
+
+	db *LoggedDB
+}
+
+// WrapDB returns database handle which logs queries executed with `db`.
+func (l *Logger) WrapDB(db *sql.DB) *LoggedDB {
+	return &LoggedDB{DB: db, Redact: redactSQLArg, logger: l}
+}
+
+// ExecContext executes query like sql.DB.ExecContext and logs it.
+func (d *LoggedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := d.DB.ExecContext(ctx, query, args...)
+	d.logQuery(start, query, args, rowsAffected(result, err), err)
+
+	return result, err
+}
+
+// Exec executes query like sql.DB.Exec and logs it.
+func (d *LoggedDB) Exec(query string, args ...any) (sql.Result, error) {
+	return d.ExecContext(context.Background(), query, args...)
+}
+
+// QueryContext executes query like sql.DB.QueryContext and logs it. Duration does not include reading of
+// rows.
+func (d *LoggedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := d.DB.QueryContext(ctx, query, args...)
+	d.logQuery(start, query, args, -1, err)
+
+	return rows, err
+}
+
+// Query executes query like sql.DB.Query and logs it.
+func (d *LoggedDB) Query(query string, args ...any) (*sql.Rows, error) {
+	return d.QueryContext(context.Background(), query, args...)
+}
+
+// QueryRowContext executes query like sql.DB.QueryRowContext and logs it.
+func (d *LoggedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := d.DB.QueryRowContext(ctx, query, args...)
+	d.logQuery(start, query, args, -1, row.Err())
+
+	return row
+}
+
+// QueryRow executes query like sql.DB.QueryRow and logs it.
+func (d *LoggedDB) QueryRow(query string, args ...any) *sql.Row {
+	return d.QueryRowContext(context.Background(), query, args...)
+}
+
+// BeginTx starts transaction like sql.DB.BeginTx. Queries of transaction are logged.
+func (d *LoggedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*LoggedTx, error) {
+	tx, err := d.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoggedTx{Tx: tx, db: d}, nil
+}
+
+// Begin starts transaction like sql.DB.Begin. Queries of transaction are logged.
+func (d *LoggedDB) Begin() (*LoggedTx, error) {
+	return d.BeginTx(context.Background(), nil)
+}
+
+// ExecContext executes query like sql.Tx.ExecContext and logs it.
+func (t *LoggedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := t.Tx.ExecContext(ctx, query, args...)
+	t.db.logQuery(start, query, args, rowsAffected(result, err), err)
+
+	return result, err
+}
+
+// Exec executes query like sql.Tx.Exec and logs it.
+func (t *LoggedTx) Exec(query string, args ...any) (sql.Result, error) {
+	return t.ExecContext(context.Background(), query, args...)
+}
+
+// QueryContext executes query like sql.Tx.QueryContext and logs it. Duration does not include reading of
+// rows.
+func (t *LoggedTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := t.Tx.QueryContext(ctx, query, args...)
+	t.db.logQuery(start, query, args, -1, err)
+
+	return rows, err
+}
+
+// Query executes query like sql.Tx.Query and logs it.
+func (t *LoggedTx) Query(query string, args ...any) (*sql.Rows, error) {
+	return t.QueryContext(context.Background(), query, args...)
+}
+
+// QueryRowContext executes query like sql.Tx.QueryRowContext and logs it.
+func (t *LoggedTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := t.Tx.QueryRowContext(ctx, query, args...)
+	t.db.logQuery(start, query, args, -1, row.Err())
+
+	return row
+}
+
+// QueryRow executes query like sql.Tx.QueryRow and logs it.
+func (t *LoggedTx) QueryRow(query string, args ...any) *sql.Row {
+	return t.QueryRowContext(context.Background(), query, args...)
+}
+
+// LogQuery writes message about query `query` with arguments `args` started at `start` like wrapped methods
+// do. It is a hook for queries executed by other means, e.g. prepared statements or driver wrappers.
+// Negative `rows` means unknown number of affected rows.
+func (d *LoggedDB) LogQuery(start time.Time, query string, args []any, rows int64, err error) {
+	d.logQuery(start, query, args, rows, err)
+}
+
+// rowsAffected returns number of rows affected by query with result `result` and error `err` or -1 if it is
+// unknown.
+func rowsAffected(result sql.Result, err error) int64 {
+	if err != nil {
+		return -1
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return -1
+	}
+
+	return n
+}
+
+// logQuery writes message about query started at `start`. Negative `rows` means unknown number of affected
+// rows.
+func (d *LoggedDB) logQuery(start time.Time, query string, args []any, rows int64, err error) {
+	duration := time.Since(start)
+
+	level := LogLevelDebug
+	if d.SlowThreshold > 0 && duration >= d.SlowThreshold {
+		level = LogLevelWarn
+	}
+
+	if !d.logger.enabled(level) {
+		return
+	}
+
+	var fields []Field
+	if len(args) > 0 {
+		var names map[int]string
+		if d.Redact != nil {
+			names = sqlArgNames(query)
+		}
+
+		values := make([]any, len(args))
+		for i, arg := range args {
+			values[i] = arg
+			if d.Redact != nil {
+				values[i] = d.Redact(names[i], arg)
+			}
+		}
+		fields = append(fields, Field{"args", rawValue("[" + joinValues(values) + "]")})
+	}
+	if rows >= 0 {
+		fields = append(fields, Field{"rows", rows})
+	}
+	fields = append(fields, Field{"duration", duration})
+	if err != nil {
+		fields = append(fields, Field{"error", err})
+	}
+
+	d.logger.log(level, strings.Join(strings.Fields(query), " "), "", fields)
+}
+
+// redactSQLArg replaces values of arguments with sensitive names.
+func redactSQLArg(name string, v any) any {
+	if arg, ok := v.(sql.NamedArg); ok {
+		if sensitiveName.MatchString(arg.Name) {
+			return rawValue(arg.Name + "=[redacted]")
+		}
+		return rawValue(arg.Name + "=" + formatValue(arg.Value))
+	}
+
+	if name != "" && sensitiveName.MatchString(name) {
+		return rawValue("[redacted]")
+	}
+
+	return v
+}
+
+// sqlArgNames returns names of columns which positional arguments of query `query` are compared with or
+// inserted to by argument index. Arguments are numbered by `$N` placeholders or by order of `?` ones.
+func sqlArgNames(query string) map[int]string {
+	names := make(map[int]string)
+	tokens := sqlToken.FindAllString(query, -1)
+
+	// column list of INSERT statement, position of value in VALUES tuple and depth of parentheses in it
+	insert := len(tokens) > 0 && strings.EqualFold(tokens[0], "INSERT")
+	var columns []string
+	inColumns, columnsDone, inValues := false, false, false
+	position, depth := 0, 0
+
+	next := 0
+	for i, token := range tokens {
+		switch {
+		case insert && !inValues && strings.EqualFold(token, "VALUES"):
+			inValues, columnsDone = true, true
+		case insert && !columnsDone && token == "(":
+			inColumns = true
+		case inColumns && token == ")":
+			inColumns, columnsDone = false, true
+		case inColumns && token != ",":
+			columns = append(columns, strings.Trim(token, `"`))
+		case inValues && token == "(":
+			if depth++; depth == 1 {
+				position = 0
+			}
+		case inValues && token == ")":
+			depth--
+		case inValues && token == "," && depth == 1:
+			position++
+		case inValues && depth == 0 && token != ",":
+			// clause after VALUES, e.g. ON CONFLICT
+			inValues = false
+		}
+
+		index := -1
+		switch {
+		case token == "?":
+			index = next
+			next++
+		case strings.HasPrefix(token, "$"):
+			if n, err := strconv.Atoi(token[1:]); err == nil {
+				index = n - 1
+			}
+		}
+		if index < 0 {
+			continue
+		}
+
+		switch {
+		case inValues && position < len(columns):
+			names[index] = columns[position]
+		case i >= 2 && isSQLComparison(strings.ToUpper(tokens[i-1])):
+			names[index] = strings.Trim(tokens[i-2], `"`)
+		}
+	}
+
+	return names
+}
+
+// isSQLComparison reports whether token `s` is comparison operator.
+func isSQLComparison(s string) bool {
+	switch s {
+	case "=", "<>", "!=", "<", ">", "<=", ">=", "LIKE":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,203 @@
+package simplelog
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testDB is a database/sql driver which records executed statements and returns no rows
+type testDB struct {
+	mu    sync.Mutex
+	execs []testExec
+
+	// committed and rolled back transactions
+	commits, rollbacks int
+}
+
+// testExec is a statement executed by testDB
+type testExec struct {
+	query string
+	args  []any
+}
+
+// open returns database handle of driver.
+func (d *testDB) open() *sql.DB {
+	return sql.OpenDB(d)
+}
+
+func (d *testDB) Connect(ctx context.Context) (driver.Conn, error) {
+	return &testConn{d}, nil
+}
+
+func (d *testDB) Driver() driver.Driver {
+	return nil
+}
+
+type testConn struct {
+	db *testDB
+}
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	return &testStmt{c.db, query}, nil
+}
+
+func (c *testConn) Close() error {
+	return nil
+}
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	return &testTx{c.db}, nil
+}
+
+func (c *testConn) CheckNamedValue(v *driver.NamedValue) error {
+	return nil
+}
+
+type testStmt struct {
+	db    *testDB
+	query string
+}
+
+func (s *testStmt) Close() error {
+	return nil
+}
+
+func (s *testStmt) NumInput() int {
+	return -1
+}
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	panic("not used")
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	panic("not used")
+}
+
+func (s *testStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	s.db.execs = append(s.db.execs, testExec{s.query, values})
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *testStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return testRows{}, nil
+}
+
+type testRows struct{}
+
+func (testRows) Columns() []string {
+	return nil
+}
+
+func (testRows) Close() error {
+	return nil
+}
+
+func (testRows) Next(dest []driver.Value) error {
+	return io.EOF
+}
+
+type testTx struct {
+	db *testDB
+}
+
+func (tx *testTx) Commit() error {
+	tx.db.mu.Lock()
+	tx.db.commits++
+	tx.db.mu.Unlock()
+
+	return nil
+}
+
+func (tx *testTx) Rollback() error {
+	tx.db.mu.Lock()
+	tx.db.rollbacks++
+	tx.db.mu.Unlock()
+
+	return nil
+}
+
+func TestSQLArgNames(t *testing.T) {
+	tests := []struct {
+		query string
+		want  map[int]string
+	}{
+		{"SELECT * FROM users WHERE name = ? AND password = ?", map[int]string{0: "name", 1: "password"}},
+		{"SELECT * FROM users WHERE u.token <> $2 AND id = $1", map[int]string{1: "u.token", 0: "id"}},
+		{"SELECT * FROM users WHERE note = '?' AND id = ?", map[int]string{0: "id"}},
+		{"INSERT INTO users (name, password) VALUES (?, ?)", map[int]string{0: "name", 1: "password"}},
+		{`INSERT INTO users ("name", "api_key") VALUES ($1, lower($2)), ($3, $4)`, map[int]string{0: "name", 1: "api_key", 2: "name", 3: "api_key"}},
+		{"INSERT INTO users (name) VALUES (?) ON CONFLICT (name) DO UPDATE SET secret = ?", map[int]string{0: "name", 1: "secret"}},
+		{"INSERT INTO users VALUES (?)", map[int]string{}},
+		{"SELECT lower(?)", map[int]string{}},
+	}
+
+	for _, test := range tests {
+		got := sqlArgNames(test.query)
+		if len(got) != len(test.want) {
+			t.Errorf("sqlArgNames(%q) = %v, want %v", test.query, got, test.want)
+			continue
+		}
+		for i, name := range test.want {
+			if got[i] != name {
+				t.Errorf("sqlArgNames(%q) = %v, want %v", test.query, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestLoggedDB(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(buf)
+	l.SetLevel(LogLevelDebug)
+
+	db := l.WrapDB(new(testDB).open())
+	defer db.Close()
+
+	if _, err := db.Exec("UPDATE users SET password = ? WHERE name = ?", "secret1", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("UPDATE users SET name = :name WHERE token = :token", sql.Named("name", "bob"), sql.Named("token", "secret2")); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec("INSERT INTO users (name, api_key) VALUES ($1, $2)", "alice", "secret3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "secret") {
+		t.Errorf("arguments are not redacted:\n%s", out)
+	}
+	for _, want := range []string{
+		`|DBG| UPDATE users SET password = ? WHERE name = ? args=[[redacted], bob] rows=1`,
+		`|DBG| UPDATE users SET name = :name WHERE token = :token args=[name=bob, token=[redacted]] rows=1`,
+		`|DBG| INSERT INTO users (name, api_key) VALUES ($1, $2) args=[alice, [redacted]] rows=1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log does not contain %q:\n%s", want, out)
+		}
+	}
+}
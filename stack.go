@@ -0,0 +1,98 @@
+package simplelog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+)
+
+// fatalExit dumps goroutine stacks if StackDumpOnFatal is set, calls functions registered by OnFatal, shuts
+// logger down to deliver queued entries of sinks and terminates program with status code 1.
+func (l *Logger) fatalExit() {
+	if l.StackDumpOnFatal {
+		l.dumpStacks()
+	}
+
+	l.runFatalHooks()
+
+	l.exit(1)
+}
+
+// exit prints summary if SummaryOnExit is set, shuts logger down to deliver queued entries of sinks and sync
+// writers and terminates program with status code `code`.
+func (l *Logger) exit(code int) {
+	if l.SummaryOnExit {
+		l.PrintSummary()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultExitShutdownTimeout)
+	l.Shutdown(ctx)
+	cancel()
+
+	os.Exit(code)
+}
+
+// dumpStacks writes stacks of all goroutines to non-terminal main writer and to writers of additional
+// outputs and syncs them. Plain text writers receive stacks as is, structured ones receive entry with
+// `stacks` field.
+func (l *Logger) dumpStacks() {
+	stacks := allStacks()
+	entry := l.newEntry(l.now(), LogLevelFatal, "goroutine stacks")
+	entry.Fields = append(entry.Fields, Field{"stacks", string(stacks)})
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.terminal() {
+		if err := writeStacks(l.writer(), l.formatEncoder(), entry, stacks); err != nil {
+			l.handleError(fmt.Errorf("write goroutine stacks: %w", err))
+		}
+	}
+
+	for _, output := range l.Outputs {
+		if output.Writer == nil {
+			continue
+		}
+
+		if err := writeStacks(output.Writer, output.Encoder, entry, stacks); err != nil {
+			l.handleError(fmt.Errorf("write goroutine stacks: %w", err))
+		}
+	}
+}
+
+// writeStacks writes goroutine stacks `stacks` to writer `w` and syncs it. Stacks are written as is if
+// encoder `enc` is nil or text encoder, otherwise entry `e` encoded by `enc` is written.
+func writeStacks(w io.Writer, enc Encoder, e *Entry, stacks []byte) error {
+	defer syncWriter(w)
+
+	if _, ok := enc.(*TextEncoder); ok || enc == nil {
+		_, err := w.Write(stacks)
+		return err
+	}
+
+	b, err := enc.Encode(e)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(b); err != nil {
+		rollback(enc)
+		return err
+	}
+
+	return nil
+}
+
+// allStacks returns stack traces of all goroutines in the same format as `GOTRACEBACK=all` does.
+func allStacks() []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
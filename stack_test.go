@@ -0,0 +1,35 @@
+package simplelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDumpStacks(t *testing.T) {
+	text, structured := new(bytes.Buffer), new(bytes.Buffer)
+
+	l := NewLogger(io.Discard)
+	l.AddOutput(NewTextEncoder(), text)
+	l.AddOutput(NewJSONEncoder(), structured)
+	l.dumpStacks()
+
+	if !strings.HasPrefix(text.String(), "goroutine ") {
+		t.Errorf("text output starts with %q, want raw stacks", text.String()[:min(text.Len(), 20)])
+	}
+
+	if lines := strings.Count(structured.String(), "\n"); lines != 1 {
+		t.Fatalf("JSON output has %d lines, want 1", lines)
+	}
+	var entry struct {
+		Stacks string `json:"stacks"`
+	}
+	if err := json.Unmarshal(structured.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(entry.Stacks, "goroutine ") {
+		t.Errorf("stacks field starts with %q", entry.Stacks[:min(len(entry.Stacks), 20)])
+	}
+}
@@ -0,0 +1,47 @@
+package simplelog
+
+import "time"
+
+// OutputStats represents statistics of writes to output
+type OutputStats struct {
+	// number of bytes written, always zero for sinks
+	Bytes int64
+
+	// number of entries written
+	Entries int64
+
+	// time of last successful write, zero if nothing was written
+	LastWrite time.Time
+}
+
+// Stats represents write statistics of main writer and additional outputs
+type Stats struct {
+	Writer OutputStats
+
+	// statistics of additional outputs in order of Logger.Outputs
+	Outputs []OutputStats
+}
+
+// record records successful write of `n` bytes at time `t`. Must be called with locked mutex.
+func (s *OutputStats) record(n int, t time.Time) {
+	s.Bytes += int64(n)
+	s.Entries++
+	s.LastWrite = t
+}
+
+// Stats returns cumulative write statistics of main writer and each additional output, e.g. to detect
+// stalled sinks by time of last write or to monitor growth of log volume.
+func (l *Logger) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := Stats{
+		Writer:  l.summary.writer,
+		Outputs: make([]OutputStats, len(l.Outputs))}
+
+	for i, output := range l.Outputs {
+		stats.Outputs[i] = output.stats
+	}
+
+	return stats
+}
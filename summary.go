@@ -0,0 +1,175 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Summary represents statistics of messages written by logger
+type Summary struct {
+	// Number of messages of each log level. Progress messages are not counted.
+	Counts map[LogLevel]int
+
+	// First and last messages of Error or Fatal level, nil if there were no such messages
+	FirstError *Entry
+	LastError  *Entry
+
+	// Total number of bytes written to main writer
+	Bytes int64
+}
+
+// summaryState represents statistics shared by all loggers writing to the same output
+type summaryState struct {
+	counts     map[LogLevel]int
+	firstError *Entry
+	lastError  *Entry
+	bytes      int64
+
+	// threshold of messages count after which action is invoked once
+	threshold *threshold
+
+	// number of messages dropped because of write errors
+	dropped int64
+
+	// is logger shut down, entries are not registered by begin after it is set
+	shutdown atomic.Bool
+
+	// number of entries being written, Shutdown waits for them before closing sinks
+	inflightMu   sync.Mutex
+	inflight     int
+	inflightDone *sync.Cond
+
+	// was main writer found closed while logger was running
+	outputClosed bool
+
+	// write statistics of main writer
+	writer OutputStats
+}
+
+func newSummaryState() *summaryState {
+	s := &summaryState{counts: make(map[LogLevel]int)}
+	s.inflightDone = sync.NewCond(&s.inflightMu)
+
+	return s
+}
+
+// begin registers entry being written. It returns false if logger is shut down.
+func (s *summaryState) begin() bool {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+
+	if s.shutdown.Load() {
+		return false
+	}
+	s.inflight++
+
+	return true
+}
+
+// end unregisters entry registered by begin.
+func (s *summaryState) end() {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+
+	if s.inflight--; s.inflight == 0 {
+		s.inflightDone.Broadcast()
+	}
+}
+
+// stop marks logger shut down and waits for entries being written.
+func (s *summaryState) stop() {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+
+	s.shutdown.Store(true)
+	for s.inflight > 0 {
+		s.inflightDone.Wait()
+	}
+}
+
+// add records written entry `e`. Must be called with locked mutex.
+func (s *summaryState) add(e *Entry, n int) {
+	s.bytes += int64(n)
+
+	if e.Level == LogLevelProgress {
+		return
+	}
+
+	s.counts[e.Level]++
+
+	if e.Level >= LogLevelError {
+		if s.firstError == nil {
+			s.firstError = e
+		}
+		s.lastError = e
+	}
+}
+
+// Summary returns statistics of messages written by logger and all loggers derived from it.
+func (l *Logger) Summary() Summary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	summary := Summary{
+		Counts:     make(map[LogLevel]int, len(l.summary.counts)),
+		FirstError: l.summary.firstError,
+		LastError:  l.summary.lastError,
+		Bytes:      l.summary.bytes}
+
+	for level, count := range l.summary.counts {
+		summary.Counts[level] = count
+	}
+
+	return summary
+}
+
+// String returns summary in human-readable form, e.g.
+// `messages: info=10 error=2, bytes: 1024, first error: "a", last error: "b"`.
+func (s Summary) String() string {
+	var counts []string
+	for level := LogLevelTrace; level <= LogLevelFatal; level++ {
+		if count := s.Counts[level]; count > 0 {
+			counts = append(counts, fmt.Sprintf("%s=%d", level, count))
+		}
+	}
+	if len(counts) == 0 {
+		counts = append(counts, "0")
+	}
+
+	str := fmt.Sprintf("messages: %s, bytes: %d", strings.Join(counts, " "), s.Bytes)
+
+	if s.FirstError != nil {
+		str += fmt.Sprintf(", first error: %q", s.FirstError.Message)
+	}
+	if s.LastError != nil && s.LastError != s.FirstError {
+		str += fmt.Sprintf(", last error: %q", s.LastError.Message)
+	}
+
+	return str
+}
+
+// PrintSummary writes summary as Info message. It is useful with defer in main function of batch jobs.
+func (l *Logger) PrintSummary() (n int, err error) {
+	return l.Info(l.Summary().String())
+}
+
+// Exit terminates program with status code `code` printing summary before if SummaryOnExit is set. Logger is
+// shut down before exit, so queued entries of sinks are delivered and writers are synced.
+func (l *Logger) Exit(code int) {
+	l.exit(code)
+}
+
+// Count returns number of messages of log level `level` written by logger and all loggers derived from it.
+func (l *Logger) Count(level LogLevel) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.summary.counts[level]
+}
+
+// Counts returns number of messages of each log level written by logger and all loggers derived from it.
+func (l *Logger) Counts() map[LogLevel]int {
+	return l.Summary().Counts
+}
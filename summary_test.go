@@ -0,0 +1,44 @@
+package simplelog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExitFlushesOutputs(t *testing.T) {
+	dir := os.Getenv("SIMPLELOG_TEST_EXIT_DIR")
+	if dir != "" {
+		f, err := os.Create(filepath.Join(dir, "main.log"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := NewLogger(NewCoalescingWriter(f))
+		l.AddSink(NewBatchSink(func(ctx context.Context, batch []*Entry) error {
+			return os.WriteFile(filepath.Join(dir, "sink.log"), []byte(batch[0].Message), 0644)
+		}, BatchOptions{Interval: time.Hour}))
+
+		l.Info("message")
+		l.Exit(3)
+	}
+
+	dir = t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestExitFlushesOutputs$")
+	cmd.Env = append(os.Environ(), "SIMPLELOG_TEST_EXIT_DIR="+dir)
+
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("process exited with %v, want status 3", err)
+	}
+
+	for _, name := range []string{"main.log", "sink.log"} {
+		if b, _ := os.ReadFile(filepath.Join(dir, name)); !strings.Contains(string(b), "message") {
+			t.Errorf("%s contains %q", name, b)
+		}
+	}
+}
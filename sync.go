@@ -0,0 +1,52 @@
+package simplelog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+)
+
+// syncer is implemented by writers which can flush written data to disk, e.g. *os.File and *File
+type syncer interface {
+	Sync() error
+}
+
+// sync syncs writers after entry `e` according to sync policy. Must be called with locked mutex.
+func (l *Logger) sync(e *Entry) {
+	if e.Level == LogLevelProgress {
+		return
+	}
+
+	if e.Level < l.SyncLevel && (l.SyncInterval <= 0 || e.Time.Sub(*l.lastSync) < l.SyncInterval) {
+		return
+	}
+
+	*l.lastSync = e.Time
+
+	if !l.terminal() {
+		syncWriter(l.writer())
+	}
+
+	for _, output := range l.Outputs {
+		if err := syncWriter(output.Writer); err != nil {
+			l.handleError(fmt.Errorf("sync log output: %w", err))
+		}
+	}
+}
+
+// syncWriter syncs writer `w` if it supports syncing. Writers which do not support syncing like pipes are
+// silently skipped.
+func syncWriter(w io.Writer) error {
+	s, ok := w.(syncer)
+	if !ok {
+		return nil
+	}
+
+	err := s.Sync()
+	if errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTSUP) {
+		return nil
+	}
+
+	return err
+}
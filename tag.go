@@ -0,0 +1,69 @@
+package simplelog
+
+import (
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tagColumn renders left-aligned tag column with stable per-tag colors
+type tagColumn struct {
+	// width of tag column
+	width int
+
+	// styles of known tags
+	styles map[string]lipgloss.Style
+
+	// renderer of tag styles, default renderer is used if nil
+	renderer *lipgloss.Renderer
+
+	mu sync.Mutex
+}
+
+func newTagColumn() *tagColumn {
+	return &tagColumn{styles: make(map[string]lipgloss.Style)}
+}
+
+// register registers tag `name` and updates column width.
+func (c *tagColumn) register(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.registerLocked(name)
+}
+
+// registerLocked registers tag. Must be called with locked mutex.
+func (c *tagColumn) registerLocked(name string) {
+	if _, exists := c.styles[name]; exists {
+		return
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(name))
+
+	style := lipgloss.NewStyle()
+	if c.renderer != nil {
+		style = c.renderer.NewStyle()
+	}
+
+	c.styles[name] = style.Foreground(tagPalette[h.Sum32()%uint32(len(tagPalette))])
+	c.width = max(c.width, lipgloss.Width(name))
+}
+
+// render returns tag column for tag `name`.
+func (c *tagColumn) render(name string, isTerminal bool) string {
+	c.mu.Lock()
+	c.registerLocked(name)
+	width, style := c.width, c.styles[name]
+	c.mu.Unlock()
+
+	tag := name + strings.Repeat(" ", max(width-lipgloss.Width(name), 0)) + " |"
+
+	if !isTerminal {
+		return tag
+	}
+
+	return style.Render(tag)
+}
@@ -0,0 +1,105 @@
+package simplelog
+
+import (
+	"fmt"
+	"time"
+)
+
+// Task is a logger of named pipeline step which records `task` field on each message, tracks start time
+// and collects warnings and errors written by it and loggers derived from it
+type Task struct {
+	*Logger
+
+	// Name of task
+	Name string
+
+	// Start time of task
+	Start time.Time
+
+	state *taskState
+}
+
+// taskState holds warnings and errors of task. Must be used with locked logger mutex.
+type taskState struct {
+	warnings []*Entry
+	errors   []*Entry
+}
+
+// TaskSummary represents result of task
+type TaskSummary struct {
+	Name     string
+	Duration time.Duration
+
+	// Warnings and errors written by task
+	Warnings []*Entry
+	Errors   []*Entry
+}
+
+// ForTask returns logger of task `name` started now.
+func (l *Logger) ForTask(name string) *Task {
+	t := &Task{
+		Name:  name,
+		Start: l.now(),
+		state: new(taskState)}
+
+	t.Logger = l.clone()
+	t.Logger.fields = mergeFields(t.Logger.fields, []Field{{"task", name}})
+	t.Logger.task = t.state
+
+	return t
+}
+
+// add collects entry `e` if it is warning or error. Must be called with locked mutex.
+func (s *taskState) add(e *Entry) {
+	switch {
+	case e.Level == LogLevelProgress:
+	case e.Level >= LogLevelError:
+		s.errors = append(s.errors, e)
+	case e.Level == LogLevelWarn:
+		s.warnings = append(s.warnings, e)
+	}
+}
+
+// Summary returns summary of task at current time.
+func (t *Task) Summary() TaskSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return TaskSummary{
+		Name:     t.Name,
+		Duration: t.Logger.now().Sub(t.Start),
+		Warnings: append([]*Entry(nil), t.state.warnings...),
+		Errors:   append([]*Entry(nil), t.state.errors...)}
+}
+
+// End writes summary of task: Info message if task had no errors or Error message otherwise.
+func (t *Task) End() (n int, err error) {
+	summary := t.Summary()
+
+	level := LogLevelInfo
+	if len(summary.Errors) > 0 {
+		level = LogLevelError
+	}
+
+	return t.p(level, summary.String())
+}
+
+// String returns summary in human-readable form, e.g. `task load finished in 1.5s: 2 warnings, 1 error`.
+func (s TaskSummary) String() string {
+	str := fmt.Sprintf("task %s finished in %s", s.Name, s.Duration.Round(time.Millisecond))
+
+	if len(s.Warnings) == 0 && len(s.Errors) == 0 {
+		return str
+	}
+
+	return fmt.Sprintf("%s: %s, %s", str, plural(len(s.Warnings), "warning"), plural(len(s.Errors), "error"))
+}
+
+// plural returns count `n` with noun `noun` in singular or plural form.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
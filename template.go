@@ -0,0 +1,77 @@
+package simplelog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Fields represents set of fields
+type Fields map[string]any
+
+// Template writes Info message made from template `tmpl` with `{key}` placeholders replaced by values of
+// fields `fields`, e.g. `user {user} logged in from {ip}`. Values are recorded as message fields too: fields
+// of placeholders first in order of appearance, then other fields sorted by key. Placeholders without
+// values are kept as is.
+func (l *Logger) Template(tmpl string, fields Fields) (n int, err error) {
+	return l.PrintTemplate(LogLevelInfo, tmpl, fields)
+}
+
+// PrintTemplate writes message of log level `logLevel` made from template `tmpl` like Template does.
+func (l *Logger) PrintTemplate(logLevel LogLevel, tmpl string, fields Fields) (n int, err error) {
+	s, keys := expandTemplate(tmpl, fields)
+
+	var rest []string
+	for key := range fields {
+		if !slices.Contains(keys, key) {
+			rest = append(rest, key)
+		}
+	}
+	slices.Sort(rest)
+	keys = append(keys, rest...)
+
+	c := l.clone()
+	for _, key := range keys {
+		c.fields = mergeFields(c.fields, []Field{{key, fields[key]}})
+	}
+
+	return c.p(logLevel, s)
+}
+
+// expandTemplate returns template with placeholders replaced by field values and keys of replaced
+// placeholders in order of appearance.
+func expandTemplate(tmpl string, fields Fields) (s string, keys []string) {
+	sb := new(strings.Builder)
+
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		key := tmpl[start+1 : end]
+		value, exists := fields[key]
+		if !exists {
+			sb.WriteString(tmpl[:end+1])
+			tmpl = tmpl[end+1:]
+			continue
+		}
+
+		sb.WriteString(tmpl[:start])
+		sb.WriteString(fmt.Sprint(fieldValue(value)))
+		tmpl = tmpl[end+1:]
+
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	sb.WriteString(tmpl)
+
+	return sb.String(), keys
+}
@@ -0,0 +1,47 @@
+package simplelog
+
+// threshold represents action invoked when number of messages of specified level or higher reaches limit
+type threshold struct {
+	level  LogLevel
+	limit  int
+	count  int
+	action func()
+}
+
+// ExitAfter terminates program with status code 1 when `n`-th message of log level `level` or higher is
+// written by logger or any logger sharing its output. It is useful for batch tools which should abort on
+// cascades of failures. Non-positive `n` disables threshold.
+func (l *Logger) ExitAfter(level LogLevel, n int) {
+	l.ExitAfterFunc(level, n, func() {
+		l.Fatalf("%d messages of %s level or higher were logged, aborting", n, level)
+	})
+}
+
+// ExitAfterFunc calls `f` once when `n`-th message of log level `level` or higher is written by logger or
+// any logger sharing its output. Non-positive `n` disables threshold.
+func (l *Logger) ExitAfterFunc(level LogLevel, n int, f func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n <= 0 {
+		l.summary.threshold = nil
+		return
+	}
+
+	l.summary.threshold = &threshold{level: level, limit: n, action: f}
+}
+
+// exceeded returns threshold action if entry `e` reached threshold. Must be called with locked mutex.
+func (s *summaryState) exceeded(e *Entry) func() {
+	t := s.threshold
+	if t == nil || e.Level == LogLevelProgress || e.Level < t.level {
+		return nil
+	}
+
+	t.count++
+	if t.count != t.limit {
+		return nil
+	}
+
+	return t.action
+}
@@ -0,0 +1,173 @@
+package simplelog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrWriteTimeout is returned by TimeoutWriter when write does not complete in time
+var ErrWriteTimeout = errors.New("log write timeout")
+
+// TimeoutWriter is a writer which limits time of writes to underlying writer, so hung writer like file on
+// unavailable network mount does not freeze logging goroutines. Writes are performed by background
+// goroutine in order. Data of timed out write remains queued and is written when underlying writer
+// unblocks; if queue is full new data is dropped.
+type TimeoutWriter struct {
+	// Timeout of single write. Zero value disables timeout, so writes wait for completion.
+	Timeout time.Duration
+
+	w     io.Writer
+	queue chan *timeoutOp
+	done  chan struct{}
+
+	closeOnce    sync.Once
+	mu           sync.RWMutex
+	closed       bool
+	errorHandler func(err error)
+}
+
+// timeoutOp represents queued write or sync operation
+type timeoutOp struct {
+	p      []byte
+	sync   bool
+	result chan error
+
+	// state of operation: pending, completed or abandoned after timeout
+	state atomic.Int32
+}
+
+const (
+	opPending int32 = iota
+	opCompleted
+	opAbandoned
+)
+
+// NewTimeoutWriter returns new writer which writes to `w` with timeout `timeout` of each write. Zero
+// timeout disables timeout.
+func NewTimeoutWriter(w io.Writer, timeout time.Duration) *TimeoutWriter {
+	tw := &TimeoutWriter{
+		Timeout: timeout,
+		w:       w,
+		queue:   make(chan *timeoutOp, defaultTimeoutQueueSize),
+		done:    make(chan struct{})}
+
+	go tw.run()
+
+	return tw
+}
+
+// SetErrorHandler sets handler of errors of writes completed after timeout.
+func (tw *TimeoutWriter) SetErrorHandler(h func(error)) {
+	tw.mu.Lock()
+	tw.errorHandler = h
+	tw.mu.Unlock()
+}
+
+// Write queues `p` for writing and waits for write completion or timeout. It returns ErrWriteTimeout if
+// write does not complete in time and ErrQueueFull if data is dropped.
+func (tw *TimeoutWriter) Write(p []byte) (n int, err error) {
+	if err := tw.do(&timeoutOp{p: append([]byte(nil), p...)}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Sync syncs underlying writer if it supports syncing. It returns ErrWriteTimeout if sync does not complete
+// in time.
+func (tw *TimeoutWriter) Sync() error {
+	return tw.do(&timeoutOp{sync: true})
+}
+
+// Close waits for queued writes up to timeout and closes underlying writer if it is io.Closer.
+func (tw *TimeoutWriter) Close() error {
+	tw.closeOnce.Do(func() {
+		tw.mu.Lock()
+		tw.closed = true
+		close(tw.queue)
+		tw.mu.Unlock()
+	})
+
+	select {
+	case <-tw.done:
+	case <-tw.timeout():
+		return ErrWriteTimeout
+	}
+
+	if c, ok := tw.w.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// do queues operation and waits for its result.
+func (tw *TimeoutWriter) do(op *timeoutOp) error {
+	op.result = make(chan error, 1)
+
+	tw.mu.RLock()
+	if tw.closed {
+		tw.mu.RUnlock()
+		return ErrSinkClosed
+	}
+	select {
+	case tw.queue <- op:
+	default:
+		tw.mu.RUnlock()
+		return ErrQueueFull
+	}
+	tw.mu.RUnlock()
+
+	select {
+	case err := <-op.result:
+		return err
+	case <-tw.timeout():
+		if op.state.CompareAndSwap(opPending, opAbandoned) {
+			return ErrWriteTimeout
+		}
+
+		// operation completed just after timeout
+		return <-op.result
+	}
+}
+
+// run performs queued operations until writer is closed.
+func (tw *TimeoutWriter) run() {
+	defer close(tw.done)
+
+	for op := range tw.queue {
+		var err error
+		if op.sync {
+			err = syncWriter(tw.w)
+		} else {
+			_, err = tw.w.Write(op.p)
+		}
+
+		if op.state.CompareAndSwap(opPending, opCompleted) {
+			op.result <- err
+			continue
+		}
+
+		tw.mu.RLock()
+		h := tw.errorHandler
+		tw.mu.RUnlock()
+
+		if err != nil && h != nil {
+			h(fmt.Errorf("write log message after timeout: %w", err))
+		}
+	}
+}
+
+// timeout returns channel which receives value after Timeout. Channel never receives value if Timeout is
+// zero.
+func (tw *TimeoutWriter) timeout() <-chan time.Time {
+	if tw.Timeout <= 0 {
+		return nil
+	}
+
+	return time.After(tw.Timeout)
+}
@@ -0,0 +1,72 @@
+package simplelog
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowWriter is a writer which blocks writes until it is released
+type slowWriter struct {
+	release chan struct{}
+	err     error
+
+	mu   sync.Mutex
+	data []byte
+}
+
+func (w *slowWriter) Write(p []byte) (n int, err error) {
+	<-w.release
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.data = append(w.data, p...)
+
+	return len(p), w.err
+}
+
+func TestTimeoutWriterZeroTimeout(t *testing.T) {
+	w := &slowWriter{release: make(chan struct{})}
+	tw := NewTimeoutWriter(w, 0)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(w.release)
+	}()
+
+	if _, err := tw.Write([]byte("message")); err != nil {
+		t.Fatalf("write without timeout failed: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if string(w.data) != "message" {
+		t.Errorf("written %q, want %q", w.data, "message")
+	}
+}
+
+func TestTimeoutWriterErrorHandler(t *testing.T) {
+	w := &slowWriter{release: make(chan struct{}), err: errors.New("write failed")}
+	tw := NewTimeoutWriter(w, 10*time.Millisecond)
+
+	errs := make(chan error, 1)
+	if _, err := tw.Write([]byte("message")); !errors.Is(err, ErrWriteTimeout) {
+		t.Fatalf("got error %v, want %v", err, ErrWriteTimeout)
+	}
+
+	// handler is replaced while write is in progress
+	tw.SetErrorHandler(func(err error) { errs <- err })
+	close(w.release)
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, w.err) {
+			t.Errorf("got error %v, want %v", err, w.err)
+		}
+	case <-time.After(time.Second):
+		t.Error("error of write completed after timeout is not handled")
+	}
+	tw.Close()
+}
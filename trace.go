@@ -0,0 +1,51 @@
+package simplelog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TracedCall represents function call traced by TraceCall
+type TracedCall struct {
+	name  string
+	start time.Time
+}
+
+// TraceCall writes Trace message about entry to function `funcName` with arguments `args` and returns call
+// to pass to TraceExit, e.g. `defer log.TraceExit(log.TraceCall("load", path))`. Nothing is formatted and
+// nil is returned if Trace messages are filtered.
+func (l *Logger) TraceCall(funcName string, args ...any) *TracedCall {
+	if !l.enabled(LogLevelTrace) {
+		return nil
+	}
+
+	l.p(LogLevelTrace, fmt.Sprintf("enter %s(%s)", funcName, joinValues(args)))
+
+	return &TracedCall{name: funcName, start: l.now()}
+}
+
+// TraceExit writes Trace message about exit from function call `call` with results `results` and call
+// duration. It does nothing if `call` is nil.
+func (l *Logger) TraceExit(call *TracedCall, results ...any) {
+	if call == nil {
+		return
+	}
+
+	s := "exit " + call.name
+	if len(results) > 0 {
+		s += " = " + joinValues(results)
+	}
+
+	l.log(LogLevelTrace, s, "", []Field{{"duration", l.now().Sub(call.start)}})
+}
+
+// joinValues returns comma-separated values `a`.
+func joinValues(a []any) string {
+	values := make([]string, len(a))
+	for i, v := range a {
+		values[i] = formatValue(v)
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,17 @@
+package simplelog
+
+// Translate writes message of log level `logLevel` identified by catalog key `key`. Terminal shows text
+// returned by Translator, while files and encoded outputs keep the key as message and record arguments
+// `args` as `args` field, so user-facing messages can be localized without breaking machine processing.
+func (l *Logger) Translate(logLevel LogLevel, key string, args ...any) (n int, err error) {
+	var display string
+	if l.Translator != nil {
+		display = sanitize(l.Translator(key, args...), l.Sanitize)
+	}
+
+	if len(args) == 0 {
+		return l.log(logLevel, key, display, nil)
+	}
+
+	return l.log(logLevel, key, display, []Field{{"args", args}})
+}
@@ -0,0 +1,41 @@
+package simplelog
+
+// verboseValue is a value of field recorded only on Debug and Trace messages unless verbose fields are
+// enabled
+type verboseValue struct {
+	value any
+}
+
+// Verbose returns field `f` marked as verbose: it is recorded only on Debug and Trace messages or if
+// VerboseFields of logger is enabled, keeping Info lines short while preserving detail in debug runs.
+func Verbose(f Field) Field {
+	return Field{f.Key, verboseValue{f.Value}}
+}
+
+// verboseFields returns fields `fields` with verbose fields unwrapped if messages of level `logLevel` record
+// them and removed otherwise. Fields are copied only if there are verbose fields.
+func (l *Logger) verboseFields(logLevel LogLevel, fields []Field) []Field {
+	var result []Field
+	for i, f := range fields {
+		v, ok := f.Value.(verboseValue)
+		if !ok {
+			if result != nil {
+				result = append(result, f)
+			}
+			continue
+		}
+
+		if result == nil {
+			result = append(make([]Field, 0, len(fields)), fields[:i]...)
+		}
+		if l.VerboseFields || logLevel <= LogLevelDebug {
+			result = append(result, Field{f.Key, v.value})
+		}
+	}
+
+	if result == nil {
+		return fields
+	}
+
+	return result
+}
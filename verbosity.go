@@ -0,0 +1,73 @@
+package simplelog
+
+// verbosityLevel returns minimum log level matching verbosity `n`.
+func verbosityLevel(n int) LogLevel {
+	switch {
+	case n < 0:
+		return LogLevelError
+	case n == 0:
+		return LogLevelInfo
+	case n == 1:
+		return LogLevelDebug
+	}
+
+	return LogLevelTrace
+}
+
+// SetVerbosity sets minimum log level by verbosity `n` like `-v` command line flags do:
+// -1 and less is Error, 0 is Info, 1 is Debug, 2 and more is Trace.
+func (l *Logger) SetVerbosity(n int) {
+	l.SetLevel(verbosityLevel(n))
+}
+
+// V reports whether messages of verbosity `n` are written, e.g. `if log.V(2) { log.Trace(dump()) }`.
+func (l *Logger) V(n int) bool {
+	return l.enabled(verbosityLevel(n))
+}
+
+// Quiet enables or disables quiet mode. Quiet mode silences Info and lower messages and all progress messages
+// but keeps warnings and errors. Disabling quiet mode restores previous level and progress settings.
+func (l *Logger) Quiet(quiet bool) {
+	if quiet == l.quiet {
+		return
+	}
+
+	l.quiet = quiet
+
+	if quiet {
+		l.quietRestore = quietState{l.Level(), l.NoProgress}
+		l.SetLevel(max(l.Level(), LogLevelWarn))
+		l.NoProgress = true
+	} else {
+		l.SetLevel(l.quietRestore.level)
+		l.NoProgress = l.quietRestore.noProgress
+	}
+}
+
+// quietState holds settings to restore after quiet mode is disabled
+type quietState struct {
+	level      LogLevel
+	noProgress bool
+}
+
+// AtLevel calls `f` with logger derived from l which writes messages of level `level` or higher, e.g. while
+// handling of flagged request. Level is only lowered: it is not changed if logger already writes messages of
+// level `level`. Level of l is not affected, per-output levels still apply.
+func (l *Logger) AtLevel(level LogLevel, f func(l *Logger)) {
+	c := l.clone()
+	c.SetLevel(min(c.Level(), level))
+
+	f(c)
+}
+
+// Elevate lowers minimum log level of logger to `level` for code region and returns function which restores
+// previous level, e.g. `defer log.Elevate(LogLevelTrace)()`. Level is not changed if logger already writes
+// messages of level `level`. Nested regions must be restored in reverse order.
+func (l *Logger) Elevate(level LogLevel) (restore func()) {
+	prev := l.Level()
+	l.SetLevel(min(prev, level))
+
+	return func() {
+		l.SetLevel(prev)
+	}
+}
@@ -0,0 +1,73 @@
+package simplelog
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// lineWriter splits written data to lines and writes every line as separate message.
+type lineWriter struct {
+	logger *Logger
+	level  LogLevel
+
+	// unterminated part of last line
+	buf []byte
+
+	mu sync.Mutex
+}
+
+func newLineWriter(logger *Logger, level LogLevel) *lineWriter {
+	return &lineWriter{logger: logger, level: level}
+}
+
+// Write writes all complete lines of `p` to logger and buffers rest of data.
+func (w *lineWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := bytes.TrimSuffix(w.buf[:i], []byte{'\r'})
+		w.buf = w.buf[i+1:]
+
+		if _, err := w.logger.p(w.level, string(line)); err != nil {
+			return len(p), err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close writes buffered unterminated line.
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	_, err := w.logger.p(w.level, string(w.buf))
+	w.buf = nil
+
+	return err
+}
+
+// StdoutWriter returns writer which writes every line as Info message, e.g. for `cmd.Stdout` of executed
+// command. Close must be called to flush last unterminated line.
+func (l *Logger) StdoutWriter() io.WriteCloser {
+	return newLineWriter(l, LogLevelInfo)
+}
+
+// StderrWriter returns writer which writes every line as Error message, e.g. for `cmd.Stderr` of executed
+// command. Close must be called to flush last unterminated line.
+func (l *Logger) StderrWriter() io.WriteCloser {
+	return newLineWriter(l, LogLevelError)
+}